@@ -36,13 +36,13 @@ func InsertOneExample(l *slog.Logger) {
 		Status:  1,
 	}
 	// Now create the query
-	sqlQuery, err := MySQL.DB.Insert(entry)
+	sqlQuery, args, err := MySQL.DB.InsertArgs(entry)
 	if err != nil {
 		l.Error(err.Error())
 		return
 	}
 	// Then execute the query
-	lastInsertedID, rowsAffected, err := MySQL.DB.Execute(sqlQuery)
+	lastInsertedID, rowsAffected, err := MySQL.DB.Execute(sqlQuery, args...)
 	if err != nil {
 		l.Error(err.Error())
 	}
@@ -59,12 +59,13 @@ func UpdateExample(l *slog.Logger) {
 		Status:  1,
 	}
 	// Now create the query
-	sqlQuery, err := MySQL.DB.Update(p)
+	sqlQuery, args, err := MySQL.DB.UpdateArgs(p)
 	if err != nil {
 		l.Error(err.Error())
+		return
 	}
 	// Then execute it
-	lastInsertedID, rowsAffected, err := MySQL.DB.Execute(sqlQuery)
+	lastInsertedID, rowsAffected, err := MySQL.DB.Execute(sqlQuery, args...)
 	if err != nil {
 		l.Error(err.Error())
 		return
@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Connect opens the connection pool and verifies it with a ping, retrying as configured by ConnectRetries and
+// ConnectRetryBackoff. It does nothing when the database is already connected. Calling it is optional, the first
+// statement connects on demand.
+func (db *Database) Connect(ctx context.Context) error {
+	_, err := db.getConnection(ctx)
+	return err
+}
+
+// Close closes the connection pool. The Database stays usable: the next statement opens a new pool.
+func (db *Database) Close() error {
+	db.Lock.Lock()
+	defer db.Lock.Unlock()
+
+	if db.dbConnection == nil {
+		db.connected = false
+		return nil
+	}
+	err := db.dbConnection.Close()
+	db.dbConnection = nil
+	db.connected = false
+	return err
+}
+
+// Ping verifies the database can be reached, connecting first when needed
+func (db *Database) Ping(ctx context.Context) error {
+	DatabaseConnection, err := db.getConnection(ctx)
+	if err != nil {
+		return err
+	}
+	return DatabaseConnection.PingContext(ctx)
+}
+
+// IsConnected reports whether the pool is open
+func (db *Database) IsConnected() bool {
+	db.Lock.Lock()
+	defer db.Lock.Unlock()
+	return db.connected && db.dbConnection != nil
+}
+
+// getConnection returns the open pool, connecting when there is none. The lock is only held to read and install the
+// pool, not while pinging, so Close, IsConnected and the pool settings don't wait for a slow connect, and each caller
+// connecting gives up on its own context.
+func (db *Database) getConnection(ctx context.Context) (*sql.DB, error) {
+	db.Lock.Lock()
+	if db.connected && db.dbConnection != nil {
+		pool := db.dbConnection
+		db.Lock.Unlock()
+		return pool, nil
+	}
+	dsn, driverName, config := db.DSN, db.driverName, db.poolConfig()
+	db.Lock.Unlock()
+
+	if dsn == "" {
+		return nil, errors.New("empty database dsn")
+	}
+
+	if driverName == "" {
+		driverName = "mysql"
+	}
+
+	// Open only validates its arguments, so there is nothing to gain from retrying it.
+	pool, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// attempt ConnectRetries times to connect (at least once), then give up
+	attempts := max(config.ConnectRetries, 1)
+	for i := 0; i < attempts; i++ {
+		// To verify that the data source name is valid, call Ping.
+		err = pool.PingContext(ctx)
+		if err == nil {
+			break // connection was fine
+		}
+		db.logger().With("attempt", i).With("error", err.Error()).Error("Unable to Ping Database")
+
+		if i == attempts-1 {
+			break
+		}
+		// wait a short while before trying again
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-time.After(config.retryDelay(i)):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if err != nil {
+		_ = pool.Close()
+		return nil, err
+	}
+
+	db.Lock.Lock()
+	defer db.Lock.Unlock()
+
+	// check once more - in case another goroutine has established a connection meanwhile
+	if db.connected && db.dbConnection != nil {
+		_ = pool.Close()
+		return db.dbConnection, nil
+	}
+
+	// the settings may have changed while pinging
+	applyPoolConfig(pool, db.poolConfig())
+	db.dbConnection = pool
+	db.connected = true
+
+	return db.dbConnection, nil
+}
@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSqliteDatabase creates a handle that connects to a fresh sqlite file on demand
+func newSqliteDatabase(t *testing.T) *Database {
+	db := NewDatabase(filepath.Join(t.TempDir(), "connection-test.db"), nil)
+	db.driverName = "sqlite3"
+	return db
+}
+
+// TestGetConnectionErrorsReleaseLock checks a failed connect doesn't leave the lock held for later calls
+func TestGetConnectionErrorsReleaseLock(t *testing.T) {
+	db := NewDatabase("", nil)
+	done := make(chan struct{})
+	go func() {
+		assert.EqualError(t, db.Connect(context.Background()), "empty database dsn")
+		assert.EqualError(t, db.Connect(context.Background()), "empty database dsn")
+
+		db.DSN = "invalid-dsn"
+		db.ConnectRetries = 1
+		assert.Error(t, db.Connect(context.Background()))
+		assert.Error(t, db.Connect(context.Background()))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("getConnection deadlocked")
+	}
+	assert.False(t, db.IsConnected())
+}
+
+func TestConnectCloseReconnect(t *testing.T) {
+	db := newSqliteDatabase(t)
+	assert.False(t, db.IsConnected())
+
+	assert.NoError(t, db.Connect(context.Background()))
+	assert.True(t, db.IsConnected())
+	assert.NoError(t, db.Ping(context.Background()))
+
+	_, _, err := db.Execute("CREATE TABLE Users (id INTEGER PRIMARY KEY, name TEXT)")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.Close())
+	assert.False(t, db.IsConnected())
+	assert.NoError(t, db.Close())
+
+	// the next statement opens a new pool
+	_, _, err = db.Execute("INSERT INTO Users(name) VALUES (?)", "Test")
+	assert.NoError(t, err)
+	assert.True(t, db.IsConnected())
+	assert.NoError(t, db.Close())
+}
+
+func TestConnectCancelledDuringBackoff(t *testing.T) {
+	db := NewDatabase("user@tcp(127.0.0.1:1)/test", nil)
+	db.ConnectRetries = 5
+	db.ConnectRetryBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, db.Connect(ctx))
+	assert.False(t, db.IsConnected())
+}
+
+// TestConnectDoesNotHoldLock checks a connect stuck retrying doesn't block the other methods or other callers
+func TestConnectDoesNotHoldLock(t *testing.T) {
+	db := NewDatabase("user@tcp(127.0.0.1:1)/test", nil)
+	db.ConnectRetries = 5
+	db.ConnectRetryBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	connected := make(chan error)
+	go func() { connected <- db.Connect(ctx) }()
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		assert.False(t, db.IsConnected())
+		db.SetPoolConfig(db.PoolConfig())
+		assert.NoError(t, db.Close())
+
+		// a second caller gives up on its own deadline
+		shortCtx, shortCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer shortCancel()
+		assert.Error(t, db.Connect(shortCtx))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("methods blocked behind a connect")
+	}
+	cancel()
+	assert.ErrorIs(t, <-connected, context.Canceled)
+}
+
+// TestConcurrentLifecycle is meant to be run with -race
+func TestConcurrentLifecycle(t *testing.T) {
+	db := newSqliteDatabase(t)
+	_, _, err := db.Execute("CREATE TABLE Users (id INTEGER PRIMARY KEY, name TEXT)")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				// statements may fail when a Close lands between getting the pool and using it, but must not race
+				_, _, _ = db.Execute("INSERT INTO Users(name) VALUES (?)", "Test")
+				_, _ = db.Query("SELECT id,name FROM Users")
+				_ = db.Ping(context.Background())
+				_ = db.IsConnected()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 10; j++ {
+			_ = db.Close()
+			db.SetPoolConfig(db.PoolConfig())
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	wg.Wait()
+
+	_, _, err = db.Execute("INSERT INTO Users(name) VALUES (?)", "Test")
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+	_ = os.Remove(db.DSN)
+}
@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteContextCancelled(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("UPDATE Users SET status=1").WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := db.ExecuteContext(ctx, "UPDATE Users SET status=1")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestQueryContextDeadline(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id FROM Users").WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := db.QueryContext(ctx, "SELECT id FROM Users")
+	assert.Error(t, err)
+}
+
+func TestQueryStructContext(t *testing.T) {
+	type Person struct {
+		Id   int    `db:"column=id primarykey=yes table=Users"`
+		Name string `db:"column=name"`
+	}
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users WHERE id=?").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test"))
+
+	person, err := QuerySingleStructContext[Person](context.Background(), db, "SELECT id,name FROM Users WHERE id=?", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Person{1, "Test"}, person)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = QueryStructContext[Person](ctx, db, "SELECT id,name FROM Users")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveContextCancelled(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec(`INSERT INTO Users(name,status) VALUES (?,?);`).WillDelayFor(time.Second).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := db.SaveContext(ctx, SavePersonTime{0, "Test", time.Now(), 31}, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = db.RecordInsertContext(ctx, Record{"name": Field{Value: "Test"}}, "Users")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,109 @@
+package mysql
+
+import (
+	"reflect"
+	"sync"
+)
+
+// converter maps a Go type that has no built-in mapping to and from its database representation. Either function
+// may be nil when the type is only read or only written.
+type converter struct {
+	read  func(Field) (any, error)
+	write func(any) (any, error)
+}
+
+// converters holds a *converter per reflect.Type
+var converters sync.Map
+
+// RegisterConverter registers how values of type T are read from and written to the database, for types that can't
+// implement sql.Scanner and driver.Valuer themselves. read is given the non-NULL column value, write returns a value
+// the driver accepts. QueryStruct, Insert, Update, the Record functions and the Field accessors consult the registered
+// converters before their built-in conversions; fields of type *T are converted too, with NULL mapping to nil.
+// Registering T again replaces its converter.
+func RegisterConverter[T any](read func(Field) (T, error), write func(T) (any, error)) {
+	c := &converter{}
+	if read != nil {
+		c.read = func(f Field) (any, error) {
+			return read(f)
+		}
+	}
+	if write != nil {
+		c.write = func(v any) (any, error) {
+			return write(v.(T))
+		}
+	}
+	converters.Store(reflect.TypeOf((*T)(nil)).Elem(), c)
+}
+
+// lookupConverter returns the converter registered for t, or for the element type when t is a pointer
+func lookupConverter(t reflect.Type) *converter {
+	if t == nil {
+		return nil
+	}
+	if c, ok := converters.Load(t); ok {
+		return c.(*converter)
+	}
+	if t.Kind() == reflect.Pointer {
+		if c, ok := converters.Load(t.Elem()); ok {
+			return c.(*converter)
+		}
+	}
+	return nil
+}
+
+// writeValue converts a value with a registered write converter into its database representation, leaving any
+// other value as it is
+func writeValue(value any) (any, error) {
+	c := lookupConverter(reflect.TypeOf(value))
+	if c == nil || c.write == nil {
+		return value, nil
+	}
+	rv := reflect.ValueOf(value)
+	if _, ok := converters.Load(rv.Type()); !ok {
+		// registered for the element type of a pointer
+		if rv.IsNil() {
+			return nil, nil
+		}
+		value = rv.Elem().Interface()
+	}
+	return c.write(value)
+}
+
+// readValue converts the column value into t with the registered read converter, allocating t's element when t is
+// a pointer. NULL converts to the zero value of t.
+func readValue(c *converter, t reflect.Type, src any) (reflect.Value, error) {
+	if src == nil {
+		return reflect.Zero(t), nil
+	}
+	v, err := c.read(Field{Value: src})
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if t.Kind() == reflect.Pointer && reflect.TypeOf(v) != t {
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(reflect.ValueOf(v))
+		return ptr, nil
+	}
+	return reflect.ValueOf(v), nil
+}
+
+// FieldAs returns the value of the Field as a T, through the converter registered for T. Without one, the value
+// must already be a T. NULL returns the zero T.
+func FieldAs[T any](F Field) (T, error) {
+	var zero T
+	if F.Value == nil {
+		return zero, nil
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if c := lookupConverter(t); c != nil && c.read != nil {
+		v, err := readValue(c, t, F.Value)
+		if err != nil {
+			return zero, newConversionError(F.Value, t.String(), err)
+		}
+		return v.Interface().(T), nil
+	}
+	if v, ok := F.Value.(T); ok {
+		return v, nil
+	}
+	return zero, newConversionError(F.Value, t.String(), ErrUnsupportedType)
+}
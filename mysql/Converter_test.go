@@ -0,0 +1,107 @@
+package mysql
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	RegisterConverter(func(f Field) (netip.Addr, error) {
+		b, err := f.Bytes()
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		addr, ok := netip.AddrFromSlice(b)
+		if !ok {
+			return netip.Addr{}, ErrUnsupportedType
+		}
+		return addr, nil
+	}, func(addr netip.Addr) (any, error) {
+		return addr.AsSlice(), nil
+	})
+	RegisterConverter(func(f Field) (time.Duration, error) {
+		ms, err := f.Int64()
+		return time.Duration(ms) * time.Millisecond, err
+	}, func(d time.Duration) (any, error) {
+		return d.Milliseconds(), nil
+	})
+}
+
+type converterType struct {
+	Id      int            `db:"column=id primarykey=yes table=Hosts"`
+	Addr    netip.Addr     `db:"column=addr"`
+	Timeout *time.Duration `db:"column=timeout"`
+}
+
+func TestConverterQueryStruct(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT * FROM Hosts").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "addr", "timeout"}).
+			AddRow(1, []byte{10, 0, 0, 1}, []byte("1500")).
+			AddRow(2, []byte{1, 2, 3}, nil))
+
+	_, err := QueryStructWith[converterType](db, "SELECT * FROM Hosts")
+	var conversionError *ConversionError
+	if assert.ErrorAs(t, err, &conversionError) {
+		assert.Equal(t, "addr", conversionError.Column)
+		assert.Equal(t, 1, conversionError.Row)
+		assert.Equal(t, "netip.Addr", conversionError.TargetType)
+	}
+
+	mock.ExpectQuery("SELECT * FROM Hosts").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "addr", "timeout"}).
+			AddRow(1, []byte{10, 0, 0, 1}, []byte("1500")).
+			AddRow(2, nil, nil))
+
+	rows, err := QueryStructWith[converterType](db, "SELECT * FROM Hosts")
+	assert.NoError(t, err)
+	timeout := 1500 * time.Millisecond
+	assert.Equal(t, []converterType{
+		{1, netip.AddrFrom4([4]byte{10, 0, 0, 1}), &timeout},
+		{2, netip.Addr{}, nil},
+	}, rows)
+}
+
+func TestConverterWrite(t *testing.T) {
+	timeout := 2 * time.Second
+	entry := converterType{1, netip.AddrFrom4([4]byte{10, 0, 0, 1}), &timeout}
+
+	query, err := DB.Insert(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Hosts(addr,timeout) VALUES (X'0a000001',2000);", query)
+
+	query, args, err := DB.UpdateArgs(converterType{1, entry.Addr, nil})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Hosts SET addr=?,timeout=? WHERE id=?;", query)
+	assert.Equal(t, []any{[]byte{10, 0, 0, 1}, nil, 1}, args)
+
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("INSERT INTO Hosts(addr,timeout) VALUES (?,?);").
+		WithArgs([]byte{10, 0, 0, 1}, int64(2000)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	_, err = db.RecordInsert(Record{"addr": Field{Value: entry.Addr}, "timeout": Field{Value: timeout}}, "Hosts")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConverterField(t *testing.T) {
+	addr, err := FieldAs[netip.Addr](Field{Value: []byte{10, 0, 0, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, netip.AddrFrom4([4]byte{10, 0, 0, 1}), addr)
+
+	_, err = FieldAs[netip.Addr](Field{Value: []byte{1}})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	name, err := FieldAs[string](Field{Value: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", name)
+
+	ms, err := Field{Value: 3 * time.Second}.Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), ms)
+}
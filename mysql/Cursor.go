@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// Cursor reads the rows of a query one at a time, straight from the driver, so large results don't have to fit in
+// memory. It must be closed once done with.
+//
+//	cursor, err := db.QueryCursor(ctx, "SELECT id,name FROM Users")
+//	if err != nil {
+//		return err
+//	}
+//	defer cursor.Close()
+//	for cursor.Next() {
+//		var user User
+//		if err := cursor.Scan(&user); err != nil {
+//			return err
+//		}
+//	}
+//	return cursor.Err()
+type Cursor struct {
+	db      *Database
+	rows    *sql.Rows
+	columns []string
+	values  []any
+	ptrs    []any
+	row     int
+	err     error
+	plan    *scanPlan
+}
+
+// QueryCursor runs a query and returns a Cursor over its rows
+func (db *Database) QueryCursor(ctx context.Context, sql string, parameters ...any) (*Cursor, error) {
+	DatabaseConnection, err := db.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.queryCursor(ctx, DatabaseConnection, sql, parameters...)
+}
+
+// queryCursor runs a query on a pool or transaction and returns a Cursor over its rows
+func (db *Database) queryCursor(ctx context.Context, exec executor, sql string, parameters ...any) (*Cursor, error) {
+	sql, parameters, err := expandSlices(sql, parameters, db.EmptySliceAsFalse)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := exec.QueryContext(ctx, sql, parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cursor{db: db, rows: rows, row: -1}
+	c.columns, c.err = rows.Columns()
+	c.values = make([]any, len(c.columns))
+	c.ptrs = make([]any, len(c.columns))
+	for i := range c.values {
+		c.ptrs[i] = &c.values[i]
+	}
+	return c, nil
+}
+
+// Columns returns the column names of the result
+func (c *Cursor) Columns() []string {
+	return c.columns
+}
+
+// Next advances to the next row, returning false when there are no more rows or an error occurred
+func (c *Cursor) Next() bool {
+	if c.err != nil {
+		return false
+	}
+	c.row++
+	return c.rows.Next()
+}
+
+// Record reads the current row into a Record
+func (c *Cursor) Record() (Record, error) {
+	if err := c.rows.Scan(c.ptrs...); err != nil {
+		return nil, err
+	}
+
+	out := make(Record, len(c.columns))
+	for i, col := range c.columns {
+		out[col] = newField(c.values[i])
+	}
+	return out, nil
+}
+
+// Scan reads the current row straight into the struct dest points to, matching the result columns to its db column
+// tags. The column destinations are resolved on the first call and reused for the following rows.
+func (c *Cursor) Scan(dest any) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, got %T", dest)
+	}
+
+	if c.plan == nil || c.plan.typ != v.Elem().Type() {
+		plan, err := newScanPlan(c.db, v.Elem().Type(), c.columns)
+		if err != nil {
+			return err
+		}
+		c.plan = plan
+	}
+	return c.plan.scan(c.rows, v.Elem(), c.row)
+}
+
+// Err returns the error, if any, that ended the iteration
+func (c *Cursor) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.rows.Err()
+}
+
+// Close releases the connection held by the cursor. It is safe to call more than once.
+func (c *Cursor) Close() error {
+	return c.rows.Close()
+}
@@ -0,0 +1,140 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type cursorPerson struct {
+	Id   int    `db:"column=id primarykey=yes table=Users"`
+	Name string `db:"column=name"`
+}
+
+func cursorRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test").AddRow(2, "Other").AddRow(3, "Third")
+}
+
+func TestQueryEach(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users WHERE id>?").WithArgs(0).WillReturnRows(cursorRows())
+
+	var names []string
+	err := db.QueryEach("SELECT id,name FROM Users WHERE id>?", func(record Record) error {
+		names = append(names, record["name"].AsString())
+		return nil
+	}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Test", "Other", "Third"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryEachStopsEarly(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users").WillReturnRows(cursorRows()).RowsWillBeClosed()
+
+	stop := errors.New("stop")
+	calls := 0
+	err := db.QueryEach("SELECT id,name FROM Users", func(record Record) error {
+		calls++
+		if record["id"].AsInt() == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryEachRowError(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users").
+		WillReturnRows(cursorRows().RowError(1, errors.New("connection lost")))
+
+	calls := 0
+	err := db.QueryEach("SELECT id,name FROM Users", func(record Record) error {
+		calls++
+		return nil
+	})
+	assert.EqualError(t, err, "connection lost")
+	assert.Equal(t, 1, calls)
+}
+
+func TestQueryStructEach(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users").WillReturnRows(cursorRows())
+
+	var people []cursorPerson
+	err := QueryStructEachWith[cursorPerson](db, "SELECT id,name FROM Users", func(p cursorPerson) error {
+		people = append(people, p)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []cursorPerson{{1, "Test"}, {2, "Other"}, {3, "Third"}}, people)
+
+	err = QueryStructEachWith[int](db, "SELECT id FROM Users", func(int) error { return nil })
+	assert.EqualError(t, err, "expected a struct type, got int")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQuerySingleStructFirstRow(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+
+	// the rows after the first are neither scanned nor read
+	mock.ExpectQuery("SELECT id,name FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test").AddRow("abc", "Other").
+			RowError(1, errors.New("second row read"))).
+		RowsWillBeClosed()
+	person, err := QuerySingleStructWith[cursorPerson](db, "SELECT id,name FROM Users")
+	assert.NoError(t, err)
+	assert.Equal(t, cursorPerson{1, "Test"}, person)
+
+	mock.ExpectQuery("SELECT id,name FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"})).
+		RowsWillBeClosed()
+	person, err = QuerySingleStructWith[cursorPerson](db, "SELECT id,name FROM Users")
+	assert.NoError(t, err)
+	assert.Zero(t, person)
+
+	mock.ExpectQuery("SELECT id,name FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test").RowError(0, errors.New("broken row")))
+	_, err = QuerySingleStructWith[cursorPerson](db, "SELECT id,name FROM Users")
+	assert.EqualError(t, err, "broken row")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCursor(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id,name FROM Users").WillReturnRows(cursorRows()).RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	cursor, err := tx.QueryCursor(context.Background(), "SELECT id,name FROM Users")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "name"}, cursor.Columns())
+
+	var people []cursorPerson
+	for cursor.Next() {
+		var p cursorPerson
+		assert.NoError(t, cursor.Scan(&p))
+		people = append(people, p)
+	}
+	assert.NoError(t, cursor.Err())
+	assert.NoError(t, cursor.Close())
+	assert.NoError(t, cursor.Close())
+	assert.Len(t, people, 3)
+
+	var notAStruct int
+	assert.EqualError(t, cursor.Scan(&notAStruct), "expected a pointer to a struct, got *int")
+
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Delete generates a parameterized delete of the row with the primary key of the structure in the argument, based on
+// its db column tags. When the structure has a softdelete=yes column, the row is marked deleted instead, see Restore.
+func (db *Database) Delete(dbStructure any) (string, []any, error) {
+	table, primaryKeys, err := db.deleteKey(dbStructure)
+	if err != nil {
+		return "", nil, err
+	}
+	where, args := keyWhere(primaryKeys)
+	sql, args := db.deleteSql(db.softDeleteField(dbStructure), table, where, args)
+	return sql, args, nil
+}
+
+// DeleteMany generates a parameterized delete of the rows with the primary keys of the elements in the argument,
+// using the package-level DB
+func DeleteMany[T any](dbStructures []T) (string, []any, error) {
+	return DeleteManyWith[T](DB, dbStructures)
+}
+
+// DeleteManyWith generates a parameterized delete of the rows with the primary keys of the elements in the argument,
+// using the given handle. The keys are bound as a single IN list, of (a,b) tuples for a composite key, so more keys
+// than the 65535 placeholders MySQL allows in one statement are refused: split them into smaller slices instead.
+func DeleteManyWith[T any](db *Database, dbStructures []T) (string, []any, error) {
+	if len(dbStructures) == 0 {
+		return "", nil, nil
+	}
+	var table string
+	var primaryKeys []columnValue
+	var softDelete *fieldInfo
+	var tuples []string
+	args := make([]any, 0, len(dbStructures))
+	for _, dbStructure := range dbStructures {
+		softDelete = db.softDeleteField(dbStructure)
+		var err error
+		table, primaryKeys, err = db.deleteKey(dbStructure)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, c := range primaryKeys {
+			args = append(args, c.value)
+		}
+		tuples = append(tuples, placeholdersSql(len(primaryKeys)))
+	}
+
+	where := primaryKeys[0].column + " IN " + placeholdersSql(len(args))
+	if len(primaryKeys) > 1 {
+		where = "(" + columnsSql(primaryKeys) + ") IN (" + strings.Join(tuples, ",") + ")"
+	}
+	sql, args := db.deleteSql(softDelete, table, where, args)
+	if len(args) > maxPlaceholders {
+		return "", nil, fmt.Errorf("deleting %d rows takes %d placeholders, more than the %d allowed in one statement", len(dbStructures), len(args), maxPlaceholders)
+	}
+	return sql, args, nil
+}
+
+// DeleteWhere generates a delete from the table of T of the rows matching the where clause, using the package-level DB.
+// The arguments are returned for binding to the placeholders in where.
+func DeleteWhere[T any](where string, args ...any) (string, []any, error) {
+	return DeleteWhereWith[T](DB, where, args...)
+}
+
+// DeleteWhereWith is DeleteWhere using the given handle
+func DeleteWhereWith[T any](db *Database, where string, args ...any) (string, []any, error) {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("expected a struct type, got %s", structType)
+	}
+	info := db.structInfo(structType)
+	if info.err != nil {
+		return "", nil, info.err
+	}
+	if info.table == "" {
+		return "", nil, fmt.Errorf("no table found in structure")
+	}
+	if strings.TrimSpace(where) == "" {
+		return "", nil, fmt.Errorf("no where clause given, refusing to delete every row")
+	}
+	if info.softDelete != nil {
+		where = "(" + where + ")"
+	}
+	sql, args := db.deleteSql(info.softDelete, info.table, where, args)
+	return sql, args, nil
+}
+
+// Delete generates a parameterized delete, see Database.Delete
+func (t *Tx) Delete(dbStructure any) (string, []any, error) {
+	return t.db.Delete(dbStructure)
+}
+
+// deleteSql renders the delete of the rows matching where. With a soft delete column it becomes an update marking the
+// rows not deleted yet as deleted now.
+func (db *Database) deleteSql(softDelete *fieldInfo, table string, where string, args []any) (string, []any) {
+	if softDelete == nil {
+		return "DELETE FROM " + table + " WHERE " + where + ";", args
+	}
+	column := softDelete.column
+	return "UPDATE " + table + " SET " + column + "=? WHERE " + where + " AND " + column + " IS NULL;", append([]any{db.now()}, args...)
+}
+
+// deleteKey returns the table and primary key columns of the structure
+func (db *Database) deleteKey(dbStructure any) (string, []columnValue, error) {
+	table, primaryKeys, _, err := db.structColumns(dbStructure)
+	if err != nil {
+		return "", nil, err
+	}
+	if table == "" {
+		return "", nil, fmt.Errorf("no table found in structure")
+	}
+	if len(primaryKeys) == 0 {
+		return "", nil, fmt.Errorf("no primary key set, unable to set a where clause")
+	}
+	return table, primaryKeys, nil
+}
@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelete(t *testing.T) {
+	New("", nil)
+	sql, args, err := DB.Delete(cursorPerson{Id: 3, Name: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM Users WHERE id=?;", sql)
+	assert.Equal(t, []any{3}, args)
+
+	_, _, err = DB.Delete(struct {
+		Id int `db:"column=id primarykey=yes"`
+	}{1})
+	assert.EqualError(t, err, "no table found in structure")
+
+	_, _, err = DB.Delete(struct {
+		Id int `db:"column=id table=Users"`
+	}{1})
+	assert.EqualError(t, err, "no primary key set, unable to set a where clause")
+}
+
+func TestDeleteMany(t *testing.T) {
+	sql, args, err := DeleteMany([]cursorPerson{{Id: 1}, {Id: 2}, {Id: 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM Users WHERE id IN (?,?,?);", sql)
+	assert.Equal(t, []any{1, 2, 3}, args)
+
+	sql, args, err = DeleteMany[cursorPerson](nil)
+	assert.NoError(t, err)
+	assert.Empty(t, sql)
+	assert.Empty(t, args)
+
+	// one IN list can't bind more keys than MySQL has placeholders
+	people := make([]cursorPerson, maxPlaceholders+1)
+	for i := range people {
+		people[i].Id = i + 1
+	}
+	_, args, err = DeleteMany(people[:maxPlaceholders])
+	assert.NoError(t, err)
+	assert.Len(t, args, maxPlaceholders)
+	_, _, err = DeleteMany(people)
+	assert.EqualError(t, err, "deleting 65536 rows takes 65536 placeholders, more than the 65535 allowed in one statement")
+
+	// the composite key parts and the soft delete time count too
+	tenants := make([]tenantPerson, maxPlaceholders/2+1)
+	for i := range tenants {
+		tenants[i] = tenantPerson{TenantId: 1, Id: i + 1}
+	}
+	_, _, err = DeleteMany(tenants)
+	assert.EqualError(t, err, "deleting 32768 rows takes 65536 placeholders, more than the 65535 allowed in one statement")
+
+	db, _, _ := stampedDatabase(t)
+	deleted := make([]softPerson, maxPlaceholders)
+	for i := range deleted {
+		deleted[i].Id = i + 1
+	}
+	_, _, err = DeleteManyWith(db, deleted)
+	assert.EqualError(t, err, "deleting 65535 rows takes 65536 placeholders, more than the 65535 allowed in one statement")
+}
+
+func TestDeleteWhere(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("DELETE FROM Users WHERE name=? AND id IN (?,?);").
+		WithArgs("Test", 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	sql, args, err := DeleteWhereWith[cursorPerson](db, "name=? AND id IN (?)", "Test", []int{1, 2})
+	assert.NoError(t, err)
+	_, rowsAffected, err := db.Execute(sql, args...)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rowsAffected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, _, err = DeleteWhere[cursorPerson]("  ")
+	assert.Error(t, err)
+
+	_, _, err = DeleteWhere[struct {
+		Id int `db:"column=id primarykey=yes"`
+	}]("id=?", 1)
+	assert.EqualError(t, err, "no table found in structure")
+}
+
+func TestDeleteCompositeKey(t *testing.T) {
+	New("", nil)
+	sql, args, err := DB.Delete(tenantPerson{TenantId: 7, Id: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM Users WHERE tenant_id=? AND id=?;", sql)
+	assert.Equal(t, []any{7, 3}, args)
+
+	sql, args, err = DeleteMany([]tenantPerson{{TenantId: 7, Id: 3}, {TenantId: 8, Id: 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM Users WHERE (tenant_id,id) IN ((?,?),(?,?));", sql)
+	assert.Equal(t, []any{7, 3, 8, 3}, args)
+}
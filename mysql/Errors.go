@@ -0,0 +1,46 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedType is wrapped by a ConversionError when there is no conversion between the two types at all
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// ErrStaleObject is returned when an update of a structure with a version=yes column matches no row, because the row
+// was changed or deleted since the structure was read
+var ErrStaleObject = errors.New("stale object: the row was changed or deleted since it was read")
+
+// ErrMissingParameter is returned when a named placeholder has no value in the parameters
+var ErrMissingParameter = errors.New("missing named parameter")
+
+// ConversionError reports a database value that could not be converted into the Go type it was read into.
+// Column is empty and Row is -1 when the value was converted outside of a query, e.g. by the Field accessors.
+type ConversionError struct {
+	Column     string
+	Row        int
+	SourceType string
+	TargetType string
+	Err        error
+}
+
+func (e *ConversionError) Error() string {
+	msg := fmt.Sprintf("cannot convert %s to %s", e.SourceType, e.TargetType)
+	if e.Column != "" {
+		msg = fmt.Sprintf("column %s, row %d: %s", e.Column, e.Row, msg)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// newConversionError describes a failed conversion of value into the named target type
+func newConversionError(value any, target string, err error) *ConversionError {
+	return &ConversionError{Row: -1, SourceType: fmt.Sprintf("%T", value), TargetType: target, Err: err}
+}
@@ -1,23 +1,40 @@
 package mysql
 
+import "context"
+
 func (db *Database) Execute(sql string, parameters ...any) (int64, int64, error) {
-    
-    DatabaseConnection, err := getConnection()
-    if err != nil {
-        return 0, 0, err
-    }
-    
-    Result, err := DatabaseConnection.Exec(sql, parameters...)
-    if err != nil {
-        return 0, 0, err
-    }
-    
-    LastInsertedID, _ := Result.LastInsertId()
-    RowsAffected, _ := Result.RowsAffected()
-    
-    if db.ShowSQL {
-        db.Logger.With("lastid", LastInsertedID).With("rows effected", RowsAffected).Info(sql)
-    }
-    
-    return LastInsertedID, RowsAffected, nil
+	return db.ExecuteContext(context.Background(), sql, parameters...)
+}
+
+// ExecuteContext runs a statement, passing the context's cancellation and deadline down to the driver.
+func (db *Database) ExecuteContext(ctx context.Context, sql string, parameters ...any) (int64, int64, error) {
+
+	DatabaseConnection, err := db.getConnection(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return db.execute(ctx, DatabaseConnection, sql, parameters...)
+}
+
+// execute runs a statement on a pool or transaction and reports the last inserted ID and rows affected
+func (db *Database) execute(ctx context.Context, exec executor, sql string, parameters ...any) (int64, int64, error) {
+
+	sql, parameters, err := expandSlices(sql, parameters, db.EmptySliceAsFalse)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	Result, err := exec.ExecContext(ctx, sql, parameters...)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	LastInsertedID, _ := Result.LastInsertId()
+	RowsAffected, _ := Result.RowsAffected()
+
+	if db.ShowSQL {
+		db.logger().With("lastid", LastInsertedID).With("rows effected", RowsAffected).Info(sql)
+	}
+
+	return LastInsertedID, RowsAffected, nil
 }
@@ -1,8 +1,7 @@
 package mysql
 
 import (
-	"fmt"
-	"reflect"
+	"math"
 	"strconv"
 	"time"
 
@@ -11,37 +10,75 @@ import (
 
 // The Database returns a map of []Records and each Record is a map of Fields.
 // This provides a way to get the Field from the Record.
+//
+// Each type has two accessors: Text, Int64, Float, ... return a *ConversionError when the value can't be converted,
+// while AsString, AsInt64, AsFloat, ... log that error and return the zero value. A NULL converts to the zero value.
 
 type Field struct {
 	Value any
 }
 
-func (F Field) AsString() string {
-
-	if F.Value == nil {
-		return ""
+// written returns the Field with a value of a type that has a registered converter replaced by its database
+// representation, so the conversions below apply to it
+func (F Field) written() (Field, error) {
+	value, err := writeValue(F.Value)
+	if err != nil {
+		return F, newConversionError(F.Value, "database value", err)
 	}
+	return Field{Value: value}, nil
+}
 
+// Text returns the value as a string
+func (F Field) Text() (string, error) {
+	F, err := F.written()
+	if err != nil {
+		return "", err
+	}
 	switch v := F.Value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []uint8:
+		return string(v), nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), nil
 	case int32:
-
+		return strconv.FormatInt(int64(v), 10), nil
 	case int64:
-		// Convert to a String
-		return strconv.FormatInt(int64(F.Value.(int64)), 10)
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
 	case float64:
-		// fmt.Printf("Float64: %v\n", val)
-		// Arry of Bytes.
-	case []uint8:
-		b, _ := F.Value.([]byte)
-		return string(b)
-	case string:
-		return F.Value.(string)
-
-	default:
-		l.Error("Can not convert type: '" + fmt.Sprintf("%T", v) + "' to a String")
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05"), nil
 	}
+	return "", newConversionError(F.Value, "string", ErrUnsupportedType)
+}
 
-	return F.Value.(string)
+func (F Field) AsString() string {
+	value, err := F.Text()
+	if err != nil {
+		l.Error(err.Error())
+	}
+	return value
 }
 
 func (F Field) AsStringPtr() *string {
@@ -54,49 +91,56 @@ func (F Field) AsStringPtr() *string {
 	return &value
 }
 
-func (F Field) AsFloat() float64 {
-
-	if F.Value == nil {
-		return 0
+// Float returns the value as a float64
+func (F Field) Float() (float64, error) {
+	F, err := F.written()
+	if err != nil {
+		return 0, err
 	}
-
 	switch v := F.Value.(type) {
+	case nil:
+		return 0, nil
 	case int:
-		return float64(F.Value.(int))
+		return float64(v), nil
 	case int8:
-		return float64(F.Value.(int8))
+		return float64(v), nil
 	case int16:
-		return float64(F.Value.(int16))
+		return float64(v), nil
 	case int32:
-		return float64(F.Value.(int32))
+		return float64(v), nil
 	case int64:
-		return float64(F.Value.(int64))
+		return float64(v), nil
 	case uint:
-		return float64(F.Value.(uint))
+		return float64(v), nil
 	case uint8:
-		return float64(F.Value.(uint8))
+		return float64(v), nil
 	case uint16:
-		return float64(F.Value.(uint16))
+		return float64(v), nil
 	case uint32:
-		return float64(F.Value.(uint32))
+		return float64(v), nil
 	case uint64:
-		return float64(F.Value.(uint64))
+		return float64(v), nil
 	case float32:
-		return float64(F.Value.(float32))
+		return float64(v), nil
 	case float64:
-		return float64(F.Value.(float64))
-	case string:
-		floatVal, err := strconv.ParseFloat(F.Value.(string), 64)
+		return v, nil
+	case string, []uint8:
+		text, _ := F.Text()
+		floatVal, err := strconv.ParseFloat(text, 64)
 		if err != nil {
-			l.With("err", err.Error()).Error(fmt.Sprintf("Cannot convert %T to float64", v))
-			return 0
+			return 0, newConversionError(F.Value, "float64", err)
 		}
-		return floatVal
-	default:
-		l.Error("Can not convert type: '" + fmt.Sprintf("%T", v) + "' to a float64")
+		return floatVal, nil
 	}
+	return 0, newConversionError(F.Value, "float64", ErrUnsupportedType)
+}
 
-	return float64(F.Value.(float64))
+func (F Field) AsFloat() float64 {
+	value, err := F.Float()
+	if err != nil {
+		l.Error(err.Error())
+	}
+	return value
 }
 
 func (F Field) AsFloatPtr() *float64 {
@@ -109,6 +153,22 @@ func (F Field) AsFloatPtr() *float64 {
 	return &value
 }
 
+// Date returns the value as a time.Time, parsing MySQL's text formats
+func (F Field) Date() (time.Time, error) {
+	F, err := F.written()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if F.Value == nil {
+		return time.Time{}, nil
+	}
+	t, err := asTime(F.Value)
+	if err != nil {
+		return time.Time{}, newConversionError(F.Value, "time.Time", err)
+	}
+	return t, nil
+}
+
 func (F Field) AsDate(d string) time.Time {
 
 	// https://github.com/go-sql-driver/mysql#timetime-support
@@ -123,17 +183,11 @@ func (F Field) AsDate(d string) time.Time {
 		}
 	}
 
-	switch v := F.Value.(type) {
-	case time.Time:
-		return F.Value.(time.Time)
-	case string:
-		t, _ := time.Parse("2006-01-02 15:04:05", F.Value.(string))
-		return t
-	default:
-		l.Error("Can not convert type: '" + fmt.Sprintf("%T", v) + "' to a Date")
-		return F.Value.(time.Time)
+	value, err := F.Date()
+	if err != nil {
+		l.Error(err.Error())
 	}
-
+	return value
 }
 
 func (F Field) AsDatePtr(d string) *time.Time {
@@ -149,102 +203,96 @@ func (F Field) AsDateEpoch() int64 {
 	if F.Value == nil {
 		return 0
 	}
-	t, _ := time.Parse("2006-01-02 15:04:05", F.Value.(string))
-
-	return t.Unix()
-}
-
-func (F Field) AsInt() int {
-
-	// TODO:  If there is a NULL in the database.  the Interface is nil.
-
-	if F.Value == nil {
+	value, err := F.Date()
+	if err != nil {
+		l.Error(err.Error())
 		return 0
 	}
+	return value.Unix()
+}
 
-	// This code is needed on each of the fields for flexiblity.  If you need to gt a Field from the database and have in the
-	// code as a different Type.  Most of the time isn't going to be needed. This is (DEFAULT) conversion
-
-	switch v := F.Value.(type) {
-	case int:
-		// Interface is a Int, so just so the conversion. (DEFAULT)
-		return F.Value.(int)
-
-	case int32:
-		return int(F.Value.(int32))
-	case int64:
-		return int(F.Value.(int64))
-	case float64:
-		return int(F.Value.(float64))
-	case float32:
-		return int(F.Value.(float32))
-	case []uint8:
-		// Interface Value is an array of Characters Convert a string, then an Int
-		b, _ := F.Value.([]byte)
-		i, _ := strconv.Atoi(string(b))
-		return i
-	case string:
-		i, _ := strconv.Atoi(F.Value.(string))
-		return i
-
-	default:
-		l.Error("Can not convert type: '" + fmt.Sprintf("%T", v) + "' to an int")
+// Int returns the value as an int
+func (F Field) Int() (int, error) {
+	value, err := F.Int64()
+	if err != nil {
+		return 0, err
 	}
-
-	return 0
+	if int64(int(value)) != value {
+		return 0, newConversionError(F.Value, "int", strconv.ErrRange)
+	}
+	return int(value), nil
 }
 
-func (F Field) AsInt64() int64 {
-
-	// TODO:  If there is a NULL in the database.  the Interface is nil.
-
-	if F.Value == nil {
-		return 0
+func (F Field) AsInt() int {
+	value, err := F.Int()
+	if err != nil {
+		l.Error(err.Error())
 	}
+	return value
+}
 
-	// This code is needed on each of the fields for flexiblity.  If you need to gt a Field from the database and have in the
-	// code as a different Type.  Most of the time isn't going to be needed. This is (DEFAULT) conversion
-
+// Int64 returns the value as an int64. Floats are truncated.
+func (F Field) Int64() (int64, error) {
+	F, err := F.written()
+	if err != nil {
+		return 0, err
+	}
 	switch v := F.Value.(type) {
+	case nil:
+		return 0, nil
 	case bool:
-		if F.Value.(bool) {
-			return int64(1)
+		if v {
+			return 1, nil
 		}
-		return int64(0)
-	case uint:
-		return int64(F.Value.(uint))
-	case uint8:
-		return int64(F.Value.(uint8))
-	case uint16:
-		return int64(F.Value.(uint16))
-	case uint32:
-		return int64(F.Value.(uint32))
-	case uint64:
-		return int64(F.Value.(uint64))
+		return 0, nil
 	case int:
-		// Interface is a Int, so just so the conversion. (DEFAULT)
-		return int64(F.Value.(int))
+		return int64(v), nil
 	case int8:
-		return int64(F.Value.(int8))
+		return int64(v), nil
 	case int16:
-		return int64(F.Value.(int16))
+		return int64(v), nil
 	case int32:
-		return int64(F.Value.(int32))
+		return int64(v), nil
 	case int64:
-		return F.Value.(int64)
-	case float64:
-		return int64(F.Value.(float64))
+		return v, nil
+	case uint:
+		return uint64ToInt64(F.Value, uint64(v))
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return uint64ToInt64(F.Value, v)
 	case float32:
-		return int64(F.Value.(float32))
-	case string:
-		i, _ := strconv.Atoi(F.Value.(string))
-		return int64(i)
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case string, []uint8:
+		text, _ := F.Text()
+		i, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return 0, newConversionError(F.Value, "int64", err)
+		}
+		return i, nil
+	}
+	return 0, newConversionError(F.Value, "int64", ErrUnsupportedType)
+}
 
-	default:
-		l.Error("Can not convert type %T %v %v", v, v, F.Value)
+func uint64ToInt64(source any, v uint64) (int64, error) {
+	if v > math.MaxInt64 {
+		return 0, newConversionError(source, "int64", strconv.ErrRange)
 	}
+	return int64(v), nil
+}
 
-	return 0
+func (F Field) AsInt64() int64 {
+	value, err := F.Int64()
+	if err != nil {
+		l.Error(err.Error())
+	}
+	return value
 }
 
 func (F Field) AsInt64Ptr() *int64 {
@@ -255,54 +303,59 @@ func (F Field) AsInt64Ptr() *int64 {
 	return &value
 }
 
-func (F Field) AsUInt64() uint64 {
-	if F.Value == nil {
-		return 0
+// UInt64 returns the value as a uint64. Negative values are out of range.
+func (F Field) UInt64() (uint64, error) {
+	F, err := F.written()
+	if err != nil {
+		return 0, err
 	}
-
-	// This code is needed on each of the fields for flexiblity.  If you need to gt a Field from the database and have in the
-	// code as a different Type.  Most of the time isn't going to be needed. This is (DEFAULT) conversion
-
 	switch v := F.Value.(type) {
+	case nil:
+		return 0, nil
 	case bool:
-		if F.Value.(bool) {
-			return uint64(1)
+		if v {
+			return 1, nil
 		}
-		return uint64(0)
+		return 0, nil
 	case uint:
-		return uint64(F.Value.(uint))
+		return uint64(v), nil
 	case uint8:
-		return uint64(F.Value.(uint8))
+		return uint64(v), nil
 	case uint16:
-		return uint64(F.Value.(uint16))
+		return uint64(v), nil
 	case uint32:
-		return uint64(F.Value.(uint32))
+		return uint64(v), nil
 	case uint64:
-		return F.Value.(uint64)
-	case int:
-		// Interface is a Int, so just so the conversion. (DEFAULT)
-		return uint64(F.Value.(int))
-	case int8:
-		return uint64(F.Value.(int8))
-	case int16:
-		return uint64(F.Value.(int16))
-	case int32:
-		return uint64(F.Value.(int32))
-	case int64:
-		return uint64(F.Value.(int64))
-	case float64:
-		return uint64(F.Value.(float64))
-	case float32:
-		return uint64(F.Value.(float32))
-	case string:
-		i, _ := strconv.Atoi(F.Value.(string))
-		return uint64(i)
-
-	default:
-		l.Error("Can not convert type %T %v %v", v, v, F.Value)
+		return v, nil
+	case int, int8, int16, int32, int64:
+		i, _ := F.Int64()
+		if i < 0 {
+			return 0, newConversionError(F.Value, "uint64", strconv.ErrRange)
+		}
+		return uint64(i), nil
+	case float32, float64:
+		f, _ := F.Float()
+		if f < 0 {
+			return 0, newConversionError(F.Value, "uint64", strconv.ErrRange)
+		}
+		return uint64(f), nil
+	case string, []uint8:
+		text, _ := F.Text()
+		i, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return 0, newConversionError(F.Value, "uint64", err)
+		}
+		return i, nil
 	}
+	return 0, newConversionError(F.Value, "uint64", ErrUnsupportedType)
+}
 
-	return 0
+func (F Field) AsUInt64() uint64 {
+	value, err := F.UInt64()
+	if err != nil {
+		l.Error(err.Error())
+	}
+	return value
 }
 
 func (F Field) AsUInt64Ptr() *uint64 {
@@ -313,45 +366,41 @@ func (F Field) AsUInt64Ptr() *uint64 {
 	return &value
 }
 
-func (F Field) AsBool() bool {
-	if F.Value == nil {
-		return false
+// Bool returns the value as a bool. Numbers are true when non-zero, strings are parsed with strconv.ParseBool.
+func (F Field) Bool() (bool, error) {
+	F, err := F.written()
+	if err != nil {
+		return false, err
 	}
-
 	switch v := F.Value.(type) {
+	case nil:
+		return false, nil
 	case bool:
-		return F.Value.(bool)
-	case uint:
-		return convToBool(F.Value.(uint))
-	case uint8:
-		return convToBool(F.Value.(uint8))
-	case uint16:
-		return convToBool(F.Value.(uint16))
-	case uint32:
-		return convToBool(F.Value.(uint32))
-	case uint64:
-		return convToBool(F.Value.(uint64))
-	case int:
-		// Interface is a Int, so just so the conversion. (DEFAULT)
-		return convToBool(F.Value.(int))
-	case int8:
-		return convToBool(F.Value.(int8))
-	case int16:
-		return convToBool(F.Value.(int16))
-	case int32:
-		return convToBool(F.Value.(int32))
-	case int64:
-		return convToBool(F.Value.(int64))
-	case float64:
-		return convToBool(F.Value.(float64))
-	case float32:
-		return convToBool(F.Value.(float32))
-	case string:
-		return len(F.Value.(string)) > 0
-	default:
-		l.Error("Can not convert type: '" + fmt.Sprintf("%T", v) + "' to a bool")
+		return v, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		f, _ := F.Float()
+		return f != 0, nil
+	case string, []uint8:
+		text, _ := F.Text()
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return false, newConversionError(F.Value, "bool", err)
+		}
+		return b, nil
+	}
+	return false, newConversionError(F.Value, "bool", ErrUnsupportedType)
+}
+
+// AsBool returns the value as a bool, logging a failed conversion. Unlike Bool, any non-empty string is true.
+func (F Field) AsBool() bool {
+	if text, ok := F.Value.(string); ok {
+		return len(text) > 0
+	}
+	value, err := F.Bool()
+	if err != nil {
+		l.Error(err.Error())
 	}
-	return false
+	return value
 }
 
 func (F Field) AsBoolPtr() *bool {
@@ -362,61 +411,33 @@ func (F Field) AsBoolPtr() *bool {
 	return &value
 }
 
-func (F Field) AsByte() []byte {
-
-	if F.Value == nil {
-		return []byte{}
+// Bytes returns the value as a byte slice
+func (F Field) Bytes() ([]byte, error) {
+	F, err := F.written()
+	if err != nil {
+		return nil, err
 	}
-
 	switch v := F.Value.(type) {
-
+	case nil:
+		return nil, nil
 	case []uint8:
-		// Interface Value is an array of Characters Convert a string, then an Int
-		b, _ := F.Value.([]byte)
-		return b
-
+		return v, nil
 	case string:
-		return []byte(F.Value.(string))
-
-	default:
-		l.Error("Can not convert type: '" + fmt.Sprintf("%T", v) + "' to a Bytes")
-	}
-	return []byte{}
-}
-
-func convToBool[T uint | uint8 | uint16 | uint32 | uint64 | int | int8 | int16 | int32 | int64 | float32 | float64](value T) bool {
-	switch any(value).(type) {
-	case uint, uint8, uint16, uint32, uint64:
-		return value != 0
-	case int, int8, int16, int32, int64:
-		return value != 0
-	case float32, float64:
-		return value != 0.0
-	default:
-		return false
+		return []byte(v), nil
 	}
+	return nil, newConversionError(F.Value, "[]byte", ErrUnsupportedType)
 }
 
-func toIntPtr[T int | int8 | int16 | int32 | int64](val *int64) reflect.Value {
-	if val == nil {
-		return reflect.ValueOf((*T)(nil))
-	}
-	tmpValue := T(*val)
-	return reflect.ValueOf(&tmpValue)
-}
+func (F Field) AsByte() []byte {
 
-func toUIntPtr[T uint | uint8 | uint16 | uint32 | uint64](val *uint64) reflect.Value {
-	if val == nil {
-		return reflect.ValueOf((*T)(nil))
+	if F.Value == nil {
+		return []byte{}
 	}
-	tmpValue := T(*val)
-	return reflect.ValueOf(&tmpValue)
-}
 
-func toFloatPtr[T float32 | float64](val *float64) reflect.Value {
-	if val == nil {
-		return reflect.ValueOf((*T)(nil))
+	value, err := F.Bytes()
+	if err != nil {
+		l.Error(err.Error())
+		return []byte{}
 	}
-	tmpValue := T(*val)
-	return reflect.ValueOf(&tmpValue)
+	return value
 }
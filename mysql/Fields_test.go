@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldConversions(t *testing.T) {
+	i, err := Field{Value: []byte("42")}.Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), i)
+
+	u, err := Field{Value: []byte("18446744073709551615")}.UInt64()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u)
+
+	s, err := Field{Value: 1.5}.Text()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", s)
+
+	b, err := Field{Value: []byte("true")}.Bool()
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	// AsBool keeps treating any non-empty string as true, Bool parses it
+	assert.True(t, Field{Value: "yes"}.AsBool())
+	assert.True(t, Field{Value: "false"}.AsBool())
+	assert.False(t, Field{Value: ""}.AsBool())
+	assert.True(t, Field{Value: int64(1)}.AsBool())
+	_, err = Field{Value: "yes"}.Bool()
+	assert.Error(t, err)
+
+	d, err := Field{Value: []byte("2024-01-08 12:30:45")}.Date()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 8, 12, 30, 45, 0, time.UTC), d)
+
+	// NULL converts to the zero value
+	i, err = Field{}.Int64()
+	assert.NoError(t, err)
+	assert.Zero(t, i)
+}
+
+func TestFieldConversionErrors(t *testing.T) {
+	_, err := Field{Value: []byte("abc")}.Int64()
+	var conversionError *ConversionError
+	if assert.ErrorAs(t, err, &conversionError) {
+		assert.Equal(t, "[]uint8", conversionError.SourceType)
+		assert.Equal(t, "int64", conversionError.TargetType)
+		assert.Equal(t, -1, conversionError.Row)
+	}
+
+	_, err = Field{Value: uint64(math.MaxUint64)}.Int64()
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = Field{Value: int64(-1)}.UInt64()
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = Field{Value: []byte("yesterday")}.Date()
+	assert.Error(t, err)
+
+	_, err = Field{Value: struct{}{}}.Text()
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	// The As* accessors fall back to the zero value instead of panicking
+	assert.Equal(t, "1.5", Field{Value: 1.5}.AsString())
+	assert.Zero(t, Field{Value: []byte("abc")}.AsInt64())
+}
+
+func TestQueryRowError(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users").
+		WillReturnRows(cursorRows().RowError(1, errors.New("connection lost")))
+
+	records, err := db.Query("SELECT id,name FROM Users")
+	assert.EqualError(t, err, "connection lost")
+	assert.Empty(t, records)
+}
+
+func TestQueryColumnsError(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).CloseError(errors.New("close failed")))
+
+	_, err := db.Query("SELECT id FROM Users")
+	assert.EqualError(t, err, "close failed")
+}
@@ -0,0 +1,25 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// executor is the part of *sql.DB and *sql.Tx that statements are run through.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Handle is implemented by *Database and *Tx, so the generic helpers such as QueryStructWith can run either against
+// the pool or inside a transaction.
+type Handle interface {
+	ExecuteContext(ctx context.Context, sql string, parameters ...any) (int64, int64, error)
+	QueryContext(ctx context.Context, sql string, parameters ...any) ([]Record, error)
+	QueryCursor(ctx context.Context, sql string, parameters ...any) (*Cursor, error)
+	database() *Database
+}
+
+func (db *Database) database() *Database {
+	return db
+}
@@ -1,126 +1,290 @@
 package mysql
 
 import (
-	"errors"
+	"database/sql/driver"
 	"fmt"
-	l "log/slog"
 	"reflect"
 	"strings"
 	"time"
 )
 
-// Insert generates an SQL query based on the db column tags provided in the structure of the argument
+// columnValue is a column of a structure together with the value to be written to it
+type columnValue struct {
+	column string
+	value  any
+	field  *fieldInfo
+}
+
+// Insert generates an SQL query based on the db column tags provided in the structure of the argument, with the
+// values written inline. Prefer InsertArgs, which binds the values as parameters.
 func (db *Database) Insert(dbStructure any) (string, error) {
-	t := reflect.TypeOf(dbStructure)
-	table, buildSql, err := generateBuildSql(dbStructure, t)
+	table, columns, err := db.insertColumns(dbStructure)
 	if err != nil {
 		return "", err
 	}
-	if table == "" {
-		return "", fmt.Errorf("no table found in structure")
-	}
-	if buildSql == "" {
-		return "", fmt.Errorf("no non-primary key and non-omitted fields found in structure")
-	}
-	valueSql, err := generateValuesSql(dbStructure, t)
+	valueSql, err := literalValuesSql(columns)
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("INSERT INTO %s(%s) VALUES %s;", table, buildSql, valueSql), nil
+	return fmt.Sprintf("INSERT INTO %s(%s) VALUES %s;", table, columnsSql(columns), valueSql), nil
+}
+
+// InsertArgs generates a parameterized insert based on the db column tags provided in the structure of the argument,
+// returning the SQL with ? placeholders and the values to bind to them
+func (db *Database) InsertArgs(dbStructure any) (string, []any, error) {
+	table, columns, err := db.insertColumns(dbStructure)
+	if err != nil {
+		return "", nil, err
+	}
+	sql, args := insertSql(table, columns)
+	return sql, args, nil
+}
+
+// insertSql renders a parameterized single-row insert of the columns
+func insertSql(table string, columns []columnValue) (string, []any) {
+	args := make([]any, 0, len(columns))
+	for _, c := range columns {
+		args = append(args, c.value)
+	}
+	return fmt.Sprintf("INSERT INTO %s(%s) VALUES %s;", table, columnsSql(columns), placeholdersSql(len(columns))), args
 }
 
-// InsertMany generates an SQL query based on the db column tags provided in the structure of the elements in the argument
+// InsertMany generates an SQL query based on the db column tags provided in the structure of the elements in the argument,
+// using the package-level DB
 func InsertMany[T any](dbStructures []T) (string, error) {
+	return InsertManyWith[T](DB, dbStructures)
+}
+
+// InsertManyWith generates an SQL query based on the db column tags provided in the structure of the elements in the
+// argument, using the given handle
+func InsertManyWith[T any](db *Database, dbStructures []T) (string, error) {
 	if len(dbStructures) == 0 {
 		return "", nil
 	}
-	t := reflect.TypeOf(dbStructures[0])
-	table, buildSql, err := generateBuildSql(dbStructures[0], t)
+	table, columns, err := db.insertColumns(dbStructures[0])
 	if err != nil {
 		return "", err
 	}
-	if table == "" {
-		return "", fmt.Errorf("no table found in structure")
-	}
-	if buildSql == "" {
-		return "", fmt.Errorf("no non-primary key and non-omitted fields found in structure")
-	}
 	var valuesSql strings.Builder
 	entriesLength := len(dbStructures)
 	for i, dbStructure := range dbStructures {
-		valueSql, err := generateValuesSql(dbStructure, t)
+		_, rowColumns, err := db.insertColumns(dbStructure)
+		if err != nil {
+			return "", err
+		}
+		if err := sameColumns(columns, rowColumns, i); err != nil {
+			return "", err
+		}
+		valueSql, err := literalValuesSql(rowColumns)
 		if err != nil {
 			return "", err
 		}
 		valuesSql.WriteString(valueSql)
 		if i < entriesLength-1 {
-			valuesSql.WriteString("\n")
+			valuesSql.WriteString(",\n")
+		}
+	}
+	return fmt.Sprintf("INSERT INTO %s(%s) VALUES %s;", table, columnsSql(columns), valuesSql.String()), nil
+}
+
+// InsertManyArgs generates a parameterized multi-row insert for the elements in the argument, using the package-level DB
+func InsertManyArgs[T any](dbStructures []T) (string, []any, error) {
+	return InsertManyArgsWith[T](DB, dbStructures)
+}
+
+// InsertManyArgsWith generates a parameterized multi-row insert for the elements in the argument, using the given
+// handle. It returns the SQL with one (?,...) tuple per element and the values to bind to them.
+func InsertManyArgsWith[T any](db *Database, dbStructures []T) (string, []any, error) {
+	if len(dbStructures) == 0 {
+		return "", nil, nil
+	}
+	var table string
+	var columns []columnValue
+	var tuples []string
+	var args []any
+	for i, dbStructure := range dbStructures {
+		t, rowColumns, err := db.insertColumns(dbStructure)
+		if err != nil {
+			return "", nil, err
+		}
+		if i == 0 {
+			table, columns = t, rowColumns
+		}
+		if err := sameColumns(columns, rowColumns, i); err != nil {
+			return "", nil, err
+		}
+		for _, c := range rowColumns {
+			args = append(args, c.value)
+		}
+		tuples = append(tuples, placeholdersSql(len(columns)))
+	}
+	return fmt.Sprintf("INSERT INTO %s(%s) VALUES %s;", table, columnsSql(columns), strings.Join(tuples, ",")), args, nil
+}
+
+// insertColumns returns the table and the columns an insert writes: the primary key parts the database doesn't
+// generate, see insertedKeys, followed by the non-primary key, non-omitted columns with the version and timestamp
+// columns filled in
+func (db *Database) insertColumns(dbStructure any) (string, []columnValue, error) {
+	table, primaryKeys, columns, err := db.structColumns(dbStructure)
+	if err != nil {
+		return "", nil, err
+	}
+	if table == "" {
+		return "", nil, fmt.Errorf("no table found in structure")
+	}
+	if len(columns) == 0 {
+		return "", nil, fmt.Errorf("no non-primary key and non-omitted fields found in structure")
+	}
+	initVersion(columns)
+	db.stampInsert(columns)
+	return table, append(insertedKeys(primaryKeys), columns...), nil
+}
+
+// insertedKeys returns the primary key parts an insert writes. A single key is taken to be auto-increment and left
+// out. Of a composite key, the part tagged autoincrement=yes is left out or, without one, the parts still zero.
+func insertedKeys(primaryKeys []columnValue) []columnValue {
+	if len(primaryKeys) < 2 {
+		return nil
+	}
+	tagged := false
+	for _, c := range primaryKeys {
+		tagged = tagged || c.field.autoIncrement
+	}
+	written := make([]columnValue, 0, len(primaryKeys))
+	for _, c := range primaryKeys {
+		if c.field.autoIncrement || !tagged && isZeroValue(c.value) {
+			continue
 		}
+		written = append(written, c)
 	}
-	return fmt.Sprintf("INSERT INTO %s(%s) VALUES %s;", table, buildSql, valuesSql.String()), nil
+	return written
 }
 
-// generateBuildSql creates the part of the insert SQL query which specifies which columns are to be inserted
-func generateBuildSql(dbStructure any, t reflect.Type) (table string, buildSql string, err error) {
-	var sb strings.Builder
+// sameColumns checks row i of a multi-row insert writes the same columns as the first
+func sameColumns(first []columnValue, row []columnValue, i int) error {
+	if columnsSql(first) != columnsSql(row) {
+		return fmt.Errorf("row %d writes columns (%s), not (%s) like the first row", i, columnsSql(row), columnsSql(first))
+	}
+	return nil
+}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag := field.Tag.Get("db")
-		dbStructureMap := decodeTag(tag)
+// structColumns returns the table of the structure, its primary key columns (none when it has no key, several for a
+// composite key) and the non-primary key, non-omitted columns with their values
+func (db *Database) structColumns(dbStructure any) (table string, primaryKeys []columnValue, columns []columnValue, err error) {
+	v := reflect.Indirect(reflect.ValueOf(dbStructure))
+	if v.Kind() != reflect.Struct {
+		return "", nil, nil, fmt.Errorf("expected a struct, got %T", dbStructure)
+	}
 
-		if reflect.ValueOf(dbStructure).Field(i).CanInterface() {
+	info := db.structInfo(v.Type())
+	if info.err != nil {
+		return "", nil, nil, info.err
+	}
 
-			if dbStructureMap["column"] == "" {
-				return "", "", errors.New("no column name specified for field " + field.Name)
-			}
+	for _, fi := range info.fields {
+		if !fi.exported {
+			continue
+		}
+		value, err := fieldValue(fi, v.FieldByIndex(fi.index))
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("column %s: %w", fi.column, err)
+		}
 
-			if dbStructureMap["table"] != "" {
-				table = dbStructureMap["table"]
-			}
+		if fi.primaryKey {
+			primaryKeys = append(primaryKeys, columnValue{column: fi.column, value: value, field: fi})
+		}
 
-			if dbStructureMap["omit"] != "yes" && dbStructureMap["primarykey"] != "yes" {
-				sb.WriteString(dbStructureMap["column"] + ",")
-			}
+		if !fi.omit && !fi.primaryKey {
+			columns = append(columns, columnValue{column: fi.column, value: value, field: fi})
 		}
 	}
+	return info.table, primaryKeys, columns, nil
+}
+
+// keyWhere renders the condition matching a row on its primary key columns, with their values to bind
+func keyWhere(primaryKeys []columnValue) (string, []any) {
+	conditions := make([]string, 0, len(primaryKeys))
+	args := make([]any, 0, len(primaryKeys))
+	for _, c := range primaryKeys {
+		conditions = append(conditions, c.column+"=?")
+		args = append(args, c.value)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// fieldValue returns the value to write for a field, going through its registered converter or driver.Valuer when
+// the field type has one. A nil pointer is written as NULL.
+func fieldValue(fi *fieldInfo, field reflect.Value) (any, error) {
+	if c := lookupConverter(fi.typ); c != nil && c.write != nil {
+		return writeValue(field.Interface())
+	}
+	if !fi.valuer {
+		return field.Interface(), nil
+	}
+	if field.Kind() == reflect.Pointer && field.IsNil() {
+		return nil, nil
+	}
+	if !field.Type().Implements(valuerType) {
+		// Value has a pointer receiver, so call it on a copy that can be addressed
+		ptr := reflect.New(field.Type())
+		ptr.Elem().Set(field)
+		field = ptr
+	}
+	return field.Interface().(driver.Valuer).Value()
+}
+
+// columnsSql joins the column names for the column list of an insert
+func columnsSql(columns []columnValue) string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.column)
+	}
+	return strings.Join(names, ",")
+}
+
+// placeholdersSql returns a (?,?,...) tuple with n placeholders
+func placeholdersSql(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?,", n), ",") + ")"
+}
+
+// literalValuesSql renders the values of the columns as a tuple of SQL literals
+func literalValuesSql(columns []columnValue) (string, error) {
+	literals := make([]string, 0, len(columns))
+	for _, c := range columns {
+		literal, err := sqlLiteral(c.value)
+		if err != nil {
+			return "", fmt.Errorf("column %s: %w", c.column, err)
+		}
+		literals = append(literals, literal)
+	}
+	return "(" + strings.Join(literals, ",") + ")", nil
+}
+
+// sqlLiteral renders a value as an SQL literal for the builders that write values inline. Strings and bytes are hex
+// encoded, so they never need escaping.
+func sqlLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case time.Time:
+		return fmt.Sprintf("'%s'", v.Format("2006-01-02 15:04:05")), nil
+	case []byte:
+		return fmt.Sprintf("X'%x'", v), nil
+	}
 
-	return table, strings.TrimSuffix(sb.String(), ","), err
-}
-
-// generateValuesSql creates the part of insert SQL query that adds each entry for each structure
-func generateValuesSql(dbStructure any, t reflect.Type) (string, error) {
-	var sb strings.Builder
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag := field.Tag.Get("db")
-		dbStructureMap := decodeTag(tag)
-
-		if reflect.ValueOf(dbStructure).Field(i).CanInterface() {
-			value := reflect.ValueOf(dbStructure).Field(i).Interface()
-
-			if dbStructureMap["column"] == "" {
-				return "", errors.New("no column name specified for field" + field.Type.Name())
-			}
-
-			if dbStructureMap["omit"] != "yes" && dbStructureMap["primarykey"] != "yes" {
-				switch field.Type.Name() {
-				case "uint", "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int", "int32", "int64":
-					sb.WriteString(fmt.Sprintf("%v,", value))
-				case "string":
-					sb.WriteString(hexRepresentation(value.(string)) + ",")
-				case "float32", "float64":
-					sb.WriteString(fmt.Sprintf("%v,", value))
-				case "bool":
-					sb.WriteString(fmt.Sprintf("%v,", value))
-				case "Time":
-					sb.WriteString(fmt.Sprintf("'%s',", value.(time.Time).Format("2006-01-02 15:04:05")))
-				default:
-					l.With("type", field.Type.Name()).With("value", value).Error("type error")
-					sb.WriteString(fmt.Sprintf(`'%s',`, value.(string)))
-				}
-			}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "NULL", nil
 		}
+		return sqlLiteral(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool:
+		return fmt.Sprintf("%v", value), nil
+	case reflect.String:
+		return hexRepresentation(rv.String()), nil
 	}
-	return fmt.Sprintf("(%s)", strings.TrimSuffix(sb.String(), ",")), nil
+	return "", fmt.Errorf("unsupported type %T", value)
 }
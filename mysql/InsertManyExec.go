@@ -0,0 +1,156 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Defaults for InsertManyOptions
+const (
+	DefaultInsertChunkRows  = 1000
+	DefaultInsertChunkBytes = 1 << 20
+
+	// maxPlaceholders is the most parameters MySQL accepts in one prepared statement
+	maxPlaceholders = 65535
+)
+
+// InsertManyOptions configures how InsertManyExec splits the rows into statements
+type InsertManyOptions struct {
+	// ChunkRows caps the rows per statement, DefaultInsertChunkRows when 0
+	ChunkRows int
+	// ChunkBytes caps the estimated size of a statement and its values, DefaultInsertChunkBytes when 0. It should stay
+	// well under the server's max_allowed_packet. A single row larger than this is still sent, on its own.
+	ChunkBytes int
+	// Transaction runs every chunk in one transaction, so a failing chunk rolls back the ones before it. It has no
+	// effect when InsertManyExec is already given a transaction.
+	Transaction bool
+}
+
+// InsertChunk reports one statement run by InsertManyExec
+type InsertChunk struct {
+	Rows         int
+	RowsAffected int64
+	// FirstID and LastID are the range of auto-increment IDs MySQL assigned to the rows of the chunk, which are
+	// consecutive for a multi-row insert. Both are 0 when the table generated none.
+	FirstID int64
+	LastID  int64
+}
+
+// InsertManyResult reports the rows inserted by InsertManyExec
+type InsertManyResult struct {
+	RowsAffected int64
+	Chunks       []InsertChunk
+}
+
+// InsertManyExec inserts the elements in the argument against the package-level DB, in as many multi-row statements
+// as the options allow. At most one InsertManyOptions is used. On error, the result reports the chunks already run and
+// kept, so it is empty when the Transaction option rolled them back.
+func InsertManyExec[T any](dbStructures []T, options ...InsertManyOptions) (InsertManyResult, error) {
+	return InsertManyExecWith[T](DB, dbStructures, options...)
+}
+
+// InsertManyExecWith is InsertManyExec against the given database or transaction
+func InsertManyExecWith[T any](h Handle, dbStructures []T, options ...InsertManyOptions) (InsertManyResult, error) {
+	return InsertManyExecContext[T](context.Background(), h, dbStructures, options...)
+}
+
+// InsertManyExecContext is InsertManyExec against the given handle under ctx
+func InsertManyExecContext[T any](ctx context.Context, h Handle, dbStructures []T, options ...InsertManyOptions) (InsertManyResult, error) {
+	var opts InsertManyOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.ChunkRows <= 0 {
+		opts.ChunkRows = DefaultInsertChunkRows
+	}
+	if opts.ChunkBytes <= 0 {
+		opts.ChunkBytes = DefaultInsertChunkBytes
+	}
+
+	if db, ok := h.(*Database); ok && opts.Transaction && len(dbStructures) > 0 {
+		var result InsertManyResult
+		err := db.WithTransactionContext(ctx, func(tx *Tx) error {
+			var err error
+			result, err = insertChunks(ctx, tx, dbStructures, opts)
+			return err
+		})
+		if err != nil {
+			return InsertManyResult{}, err
+		}
+		return result, nil
+	}
+	return insertChunks(ctx, h, dbStructures, opts)
+}
+
+// insertChunks groups the rows into statements within the row, byte and placeholder limits and runs them in order
+func insertChunks[T any](ctx context.Context, h Handle, dbStructures []T, opts InsertManyOptions) (InsertManyResult, error) {
+	var result InsertManyResult
+	var table string
+	var columns []columnValue
+	var args []any
+	rows, size := 0, 0
+
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		tuples := strings.TrimSuffix(strings.Repeat(placeholdersSql(len(columns))+",", rows), ",")
+		sql := fmt.Sprintf("INSERT INTO %s(%s) VALUES %s;", table, columnsSql(columns), tuples)
+		id, rowsAffected, err := h.ExecuteContext(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("chunk %d: %w", len(result.Chunks)+1, err)
+		}
+
+		chunk := InsertChunk{Rows: rows, RowsAffected: rowsAffected}
+		if id > 0 {
+			chunk.FirstID = id
+			chunk.LastID = id + int64(rows) - 1
+		}
+		result.Chunks = append(result.Chunks, chunk)
+		result.RowsAffected += rowsAffected
+		args, rows, size = nil, 0, 0
+		return nil
+	}
+
+	for _, dbStructure := range dbStructures {
+		t, rowColumns, err := h.database().insertColumns(dbStructure)
+		if err != nil {
+			return result, err
+		}
+		rowSize := rowBytes(rowColumns)
+		maxRows := min(opts.ChunkRows, maxPlaceholders/len(rowColumns))
+		// rows writing other key columns than the chunk so far, as with zero composite key parts, start a new one
+		if rows > 0 && (rows >= maxRows || size+rowSize > opts.ChunkBytes || columnsSql(columns) != columnsSql(rowColumns)) {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+		if rows == 0 {
+			table, columns = t, rowColumns
+			size = len("INSERT INTO ") + len(table) + len(columnsSql(columns)) + len("() VALUES ;")
+		}
+		for _, c := range rowColumns {
+			args = append(args, c.value)
+		}
+		rows++
+		size += rowSize
+	}
+	return result, flush()
+}
+
+// rowBytes estimates the size of a row's placeholders and bound values in a statement
+func rowBytes(columns []columnValue) int {
+	size := len(placeholdersSql(len(columns))) + 1
+	for _, c := range columns {
+		switch v := c.value.(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		default:
+			size += 8
+		}
+	}
+	return size
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertManyExecChunkRows(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("INSERT INTO Users(name,status) VALUES (?,?),(?,?);").
+		WithArgs("Test", 1, "Test", 1).
+		WillReturnResult(sqlmock.NewResult(10, 2))
+	mock.ExpectExec("INSERT INTO Users(name,status) VALUES (?,?),(?,?);").
+		WithArgs("Test", 1, "Test", 1).
+		WillReturnResult(sqlmock.NewResult(12, 2))
+	mock.ExpectExec("INSERT INTO Users(name,status) VALUES (?,?);").
+		WithArgs("Test", 1).
+		WillReturnResult(sqlmock.NewResult(14, 1))
+
+	result, err := InsertManyExecWith(db, generateArrayInsertPerson(1), InsertManyOptions{ChunkRows: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, InsertManyResult{
+		RowsAffected: 5,
+		Chunks: []InsertChunk{
+			{Rows: 2, RowsAffected: 2, FirstID: 10, LastID: 11},
+			{Rows: 2, RowsAffected: 2, FirstID: 12, LastID: 13},
+			{Rows: 1, RowsAffected: 1, FirstID: 14, LastID: 14},
+		},
+	}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	result, err = InsertManyExecWith[InsertPerson[int]](db, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Chunks)
+}
+
+func TestInsertManyExecChunkBytes(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	long := strings.Repeat("x", 600)
+	rows := []InsertPerson[string]{{Name: "a", Status: long}, {Name: "b", Status: long}, {Name: "c", Status: "short"}}
+	mock.ExpectExec("INSERT INTO Users(name,status) VALUES (?,?);").
+		WithArgs("a", long).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO Users(name,status) VALUES (?,?),(?,?);").
+		WithArgs("b", long, "c", "short").
+		WillReturnResult(sqlmock.NewResult(2, 2))
+
+	result, err := InsertManyExecWith(db, rows, InsertManyOptions{ChunkBytes: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.RowsAffected)
+	assert.Len(t, result.Chunks, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertManyExecTransaction(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO Users(name,status) VALUES (?,?),(?,?),(?,?);").
+		WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectExec("INSERT INTO Users(name,status) VALUES (?,?),(?,?);").
+		WillReturnError(errors.New("duplicate entry"))
+	mock.ExpectRollback()
+
+	result, err := InsertManyExecWith(db, generateArrayInsertPerson(1), InsertManyOptions{ChunkRows: 3, Transaction: true})
+	assert.EqualError(t, err, "chunk 2: duplicate entry")
+	assert.Equal(t, InsertManyResult{}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
@@ -70,37 +71,37 @@ func testInsertTimeErrorValueHelper(t *testing.T, sql string, err error) {
 
 func testInsertManyNumericalErrorValueHelper(t *testing.T, sql string, err error) {
 	assert.NoError(t, err)
-	assert.Equal(t, `INSERT INTO Users(name,status) VALUES (X'54657374',1)
-(X'54657374',1)
-(X'54657374',1)
-(X'54657374',1)
+	assert.Equal(t, `INSERT INTO Users(name,status) VALUES (X'54657374',1),
+(X'54657374',1),
+(X'54657374',1),
+(X'54657374',1),
 (X'54657374',1);`, sql)
 }
 
 func testInsertManyStringErrorValueHelper(t *testing.T, sql string, err error) {
 	assert.NoError(t, err)
-	assert.Equal(t, `INSERT INTO Users(name,status) VALUES (X'54657374',X'31')
-(X'54657374',X'31')
-(X'54657374',X'31')
-(X'54657374',X'31')
+	assert.Equal(t, `INSERT INTO Users(name,status) VALUES (X'54657374',X'31'),
+(X'54657374',X'31'),
+(X'54657374',X'31'),
+(X'54657374',X'31'),
 (X'54657374',X'31');`, sql)
 }
 
 func testInsertManyBoolErrorValueHelper(t *testing.T, sql string, err error) {
 	assert.NoError(t, err)
-	assert.Equal(t, `INSERT INTO Users(name,status) VALUES (X'54657374',true)
-(X'54657374',true)
-(X'54657374',true)
-(X'54657374',true)
+	assert.Equal(t, `INSERT INTO Users(name,status) VALUES (X'54657374',true),
+(X'54657374',true),
+(X'54657374',true),
+(X'54657374',true),
 (X'54657374',true);`, sql)
 }
 
 func testInsertManyTimeErrorValueHelper(t *testing.T, sql string, err error) {
 	assert.NoError(t, err)
-	assert.Equal(t, `INSERT INTO Users(name,dtadded) VALUES (X'54657374','2024-12-07 15:29:25')
-(X'54657374','2024-12-07 15:29:25')
-(X'54657374','2024-12-07 15:29:25')
-(X'54657374','2024-12-07 15:29:25')
+	assert.Equal(t, `INSERT INTO Users(name,dtadded) VALUES (X'54657374','2024-12-07 15:29:25'),
+(X'54657374','2024-12-07 15:29:25'),
+(X'54657374','2024-12-07 15:29:25'),
+(X'54657374','2024-12-07 15:29:25'),
 (X'54657374','2024-12-07 15:29:25');`, sql)
 }
 
@@ -284,3 +285,100 @@ func TestNoFieldsInsert(t *testing.T) {
 	assert.EqualError(t, err, "no non-primary key and non-omitted fields found in structure")
 	assert.Empty(t, sql)
 }
+
+func TestInsertArgs(t *testing.T) {
+	New("", nil)
+	sql, args, err := DB.InsertArgs(generateInsertPerson(uint(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,status) VALUES (?,?);", sql)
+	assert.Equal(t, []any{"Test", uint(1)}, args)
+
+	// values that would break out of a quoted literal are bound, never interpolated
+	sql, args, err = DB.InsertArgs(generateInsertPerson("1'); DROP TABLE Users; --"))
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,status) VALUES (?,?);", sql)
+	assert.Equal(t, []any{"Test", "1'); DROP TABLE Users; --"}, args)
+
+	entry := generateInsertPersonTime(0)
+	sql, args, err = DB.InsertArgs(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,dtadded) VALUES (?,?);", sql)
+	assert.Equal(t, []any{"Test", entry.Dtadded}, args)
+
+	_, _, err = DB.InsertArgs(struct {
+		Id int `db:"column=id primarykey=yes"`
+	}{})
+	assert.EqualError(t, err, "no table found in structure")
+}
+
+func TestInsertManyArgs(t *testing.T) {
+	sql, args, err := InsertManyArgs[InsertPerson[int]](generateArrayInsertPerson(int(1))[:3])
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,status) VALUES (?,?),(?,?),(?,?);", sql)
+	assert.Equal(t, []any{"Test", 1, "Test", 1, "Test", 1}, args)
+
+	sql, args, err = InsertManyArgs[InsertPerson[int]](nil)
+	assert.NoError(t, err)
+	assert.Empty(t, sql)
+	assert.Empty(t, args)
+}
+
+func TestInsertUnsupportedType(t *testing.T) {
+	type testType struct {
+		Id   int            `db:"column=id primarykey=yes table=Users"`
+		Tags map[string]int `db:"column=tags"`
+	}
+	sql, err := DB.Insert(testType{0, map[string]int{}})
+	assert.EqualError(t, err, "column tags: unsupported type map[string]int")
+	assert.Empty(t, sql)
+}
+
+func TestInsertValuer(t *testing.T) {
+	type testType struct {
+		Id      int            `db:"column=id primarykey=yes table=Users"`
+		Name    sql.NullString `db:"column=name"`
+		Balance cents          `db:"column=balance"`
+		Credit  *cents         `db:"column=credit"`
+		Grade   grade          `db:"column=grade"`
+	}
+	entry := testType{0, sql.NullString{}, 1234, nil, 1}
+
+	query, err := DB.Insert(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,balance,credit,grade) VALUES (NULL,X'31322e3334',NULL,X'68696768');", query)
+
+	query, args, err := DB.InsertArgs(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,balance,credit,grade) VALUES (?,?,?,?);", query)
+	assert.Equal(t, []any{nil, "12.34", nil, "high"}, args)
+
+	query, args, err = InsertManyArgs[testType]([]testType{entry, {0, sql.NullString{String: "Test", Valid: true}, 1, nil, 0}})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,balance,credit,grade) VALUES (?,?,?,?),(?,?,?,?);", query)
+	assert.Equal(t, []any{nil, "12.34", nil, "high", "Test", "0.01", nil, "low"}, args)
+}
+
+func TestInsertCompositeKey(t *testing.T) {
+	New("", nil)
+	sql, args, err := DB.InsertArgs(tenantPerson{TenantId: 7, Name: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(tenant_id,name) VALUES (?,?);", sql)
+	assert.Equal(t, []any{7, "Test"}, args)
+
+	// a natural composite key is written whole
+	sql, _, err = DB.InsertArgs(tenantPerson{7, 3, "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(tenant_id,id,name) VALUES (?,?,?);", sql)
+
+	type taggedPerson struct {
+		TenantId int    `db:"column=tenant_id primarykey=yes table=Users"`
+		Id       int    `db:"column=id primarykey=yes autoincrement=yes"`
+		Name     string `db:"column=name"`
+	}
+	sql, _, err = DB.InsertArgs(taggedPerson{7, 3, "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(tenant_id,name) VALUES (?,?);", sql)
+
+	_, _, err = InsertManyArgs([]tenantPerson{{TenantId: 7, Name: "a"}, {7, 3, "b"}})
+	assert.EqualError(t, err, "row 1 writes columns (tenant_id,id,name), not (tenant_id,name) like the first row")
+}
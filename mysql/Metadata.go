@@ -0,0 +1,264 @@
+package mysql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// fieldInfo is the mapping of one struct field to a database column, decoded once from its db tag
+type fieldInfo struct {
+	name       string
+	index      []int
+	column     string
+	typ        reflect.Type
+	kind       reflect.Kind
+	typeName   string
+	exported   bool
+	primaryKey bool
+	// autoIncrement marks the primary key part the database generates, left out of inserts
+	autoIncrement bool
+	omit          bool
+	// version marks the column used for optimistic locking, autoCreate, autoUpdate and softDelete the timestamp columns
+	version    bool
+	autoCreate bool
+	autoUpdate bool
+	softDelete bool
+	// scanner is set when the field is read through sql.Scanner, valuer when it is written through driver.Valuer
+	scanner bool
+	valuer  bool
+	tag     map[string]string
+}
+
+// structInfo is the mapping of a struct type to a table, shared by the query and write paths
+type structInfo struct {
+	typ     reflect.Type
+	table   string
+	fields  []*fieldInfo
+	columns map[string]*fieldInfo
+	// primaryKeys are the primarykey=yes fields, in declaration order, which together identify a row
+	primaryKeys []*fieldInfo
+	version     *fieldInfo
+	softDelete  *fieldInfo
+	// naming names the columns of untagged fields, nil when every field needs a column tag
+	naming *NamingStrategy
+	// err is set when an exported field has no column name, which the write paths refuse
+	err error
+	// unsupported is set when the struct can't be read either, such as one embedding a pointer to a struct
+	unsupported error
+}
+
+// structKey identifies the metadata of a struct type under a naming strategy
+type structKey struct {
+	typ    reflect.Type
+	naming *NamingStrategy
+}
+
+// structCache holds a *structInfo per structKey
+var structCache sync.Map
+
+// getStructInfo returns the cached metadata for a struct type mapped by its db tags alone
+func getStructInfo(t reflect.Type) *structInfo {
+	return getNamedStructInfo(t, nil)
+}
+
+// structInfo returns the cached metadata for a struct type, naming untagged fields with the strategy of the database
+func (db *Database) structInfo(t reflect.Type) *structInfo {
+	if db == nil {
+		return getStructInfo(t)
+	}
+	return getNamedStructInfo(t, db.Naming)
+}
+
+// getNamedStructInfo returns the cached metadata for a struct type under the naming strategy, building it on first use
+func getNamedStructInfo(t reflect.Type, naming *NamingStrategy) *structInfo {
+	key := structKey{t, naming}
+	if cached, ok := structCache.Load(key); ok {
+		return cached.(*structInfo)
+	}
+	info, _ := structCache.LoadOrStore(key, buildStructInfo(t, naming))
+	return info.(*structInfo)
+}
+
+// buildStructInfo walks the fields of the struct type and decodes their db tags
+func buildStructInfo(t reflect.Type, naming *NamingStrategy) *structInfo {
+	info := &structInfo{
+		typ:     t,
+		columns: make(map[string]*fieldInfo),
+		naming:  naming,
+	}
+	info.addFields(t, nil, "")
+	info.resolveColumns()
+	if info.table == "" {
+		info.table = inferTable(t, naming)
+	}
+	return info
+}
+
+// addFields adds the fields of the struct type t, found at index within the top-level struct, with prefix in front of
+// their column names. Embedded structs without a column, and nested structs tagged prefix=, are flattened into the
+// columns of their parent. Fields tagged db:"-" are skipped.
+func (info *structInfo) addFields(t reflect.Type, index []int, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("db") == "-" {
+			continue
+		}
+		dbStructureMap := decodeTag(field.Tag.Get("db"))
+		fieldIndex := append(index[:len(index):len(index)], i)
+
+		if isFlattened(field, dbStructureMap) {
+			info.addFields(field.Type, fieldIndex, prefix+dbStructureMap["prefix"])
+			continue
+		}
+		if isEmbeddedPointer(field, dbStructureMap) {
+			if info.unsupported == nil {
+				info.unsupported = fmt.Errorf("embedded pointer struct %s is not supported, embed it by value", field.Name)
+			}
+			if info.err == nil {
+				info.err = info.unsupported
+			}
+			continue
+		}
+
+		fi := &fieldInfo{
+			name:          field.Name,
+			index:         fieldIndex,
+			column:        dbStructureMap["column"],
+			typ:           field.Type,
+			kind:          field.Type.Kind(),
+			typeName:      fieldTypeName(field.Type),
+			exported:      field.IsExported(),
+			primaryKey:    dbStructureMap["primarykey"] == "yes",
+			autoIncrement: dbStructureMap["autoincrement"] == "yes",
+			omit:          dbStructureMap["omit"] == "yes",
+			version:       dbStructureMap["version"] == "yes",
+			autoCreate:    dbStructureMap["autocreate"] == "yes",
+			autoUpdate:    dbStructureMap["autoupdate"] == "yes",
+			softDelete:    dbStructureMap["softdelete"] == "yes",
+			scanner:       implements(field.Type, scannerType),
+			valuer:        implements(field.Type, valuerType),
+			tag:           dbStructureMap,
+		}
+		info.fields = append(info.fields, fi)
+
+		if !fi.exported {
+			continue
+		}
+
+		if fi.column == "" && info.naming != nil {
+			fi.column = info.naming.name(field.Name)
+		}
+		if fi.column == "" {
+			if info.err == nil {
+				info.err = errors.New("no column name specified for field " + field.Name)
+			}
+			continue
+		}
+		fi.column = prefix + fi.column
+
+		if fi.softDelete && !isNullable(fi) && info.err == nil {
+			info.err = fmt.Errorf("softdelete column %s must be nullable, e.g. *time.Time or sql.NullTime", fi.column)
+		}
+
+		if dbStructureMap["table"] != "" {
+			info.table = dbStructureMap["table"]
+		}
+	}
+}
+
+// resolveColumns maps each column to its field the way Go resolves promoted fields: of the fields mapped to the same
+// column, the least nested one wins and the others are dropped, and two at the same depth are an error
+func (info *structInfo) resolveColumns() {
+	for _, fi := range info.fields {
+		if !fi.exported || fi.column == "" {
+			continue
+		}
+		if existing, exists := info.columns[fi.column]; !exists || len(fi.index) < len(existing.index) {
+			info.columns[fi.column] = fi
+		}
+	}
+	for _, fi := range info.fields {
+		if !fi.exported || fi.column == "" {
+			continue
+		}
+		winner := info.columns[fi.column]
+		if winner != fi && len(fi.index) == len(winner.index) && info.err == nil {
+			info.err = fmt.Errorf("column %s is mapped by both %s and %s", fi.column, winner.name, fi.name)
+		}
+	}
+
+	fields := make([]*fieldInfo, 0, len(info.fields))
+	for _, fi := range info.fields {
+		if fi.exported && fi.column != "" {
+			if info.columns[fi.column] != fi {
+				continue
+			}
+			if fi.primaryKey {
+				info.primaryKeys = append(info.primaryKeys, fi)
+			}
+			if fi.version {
+				info.version = fi
+			}
+			if fi.softDelete {
+				info.softDelete = fi
+			}
+		}
+		fields = append(fields, fi)
+	}
+	info.fields = fields
+}
+
+// isFlattened reports whether the fields of a struct field are mapped as columns of its parent: an embedded struct
+// without a column name, or a nested struct tagged with a prefix=. Structs read and written as one value, such as
+// time.Time and sql.Scanner implementations, are never flattened, nor are pointers to structs.
+func isFlattened(field reflect.StructField, dbStructureMap map[string]string) bool {
+	if field.Type.Kind() != reflect.Struct || field.Type == timeType || dbStructureMap["column"] != "" {
+		return false
+	}
+	if implements(field.Type, scannerType) || implements(field.Type, valuerType) || lookupConverter(field.Type) != nil {
+		return false
+	}
+	_, prefixed := dbStructureMap["prefix"]
+	return field.Anonymous || prefixed && field.IsExported()
+}
+
+// isEmbeddedPointer reports whether the field embeds a pointer to a struct that would otherwise be flattened, whose
+// columns can't be written while it is nil nor read without allocating it
+func isEmbeddedPointer(field reflect.StructField, dbStructureMap map[string]string) bool {
+	if !field.Anonymous || field.Type.Kind() != reflect.Pointer {
+		return false
+	}
+	elem := field
+	elem.Type = field.Type.Elem()
+	return isFlattened(elem, dbStructureMap)
+}
+
+// isNullable reports whether the field can hold NULL: a pointer, or a type both scanned and written through
+// sql.Scanner and driver.Valuer, such as sql.NullTime
+func isNullable(fi *fieldInfo) bool {
+	return fi.kind == reflect.Pointer || fi.scanner && fi.valuer
+}
+
+// fieldTypeName names a field type the way QueryStruct dispatches on it, e.g. "int", "*Time" or "[]uint8"
+func fieldTypeName(t reflect.Type) string {
+	if t == reflect.TypeOf([]uint8{}) {
+		return "[]uint8"
+	} else if t.Kind() == reflect.Pointer {
+		return "*" + t.Elem().Name()
+	}
+	return t.Name()
+}
+
+// implements reports whether t or a pointer to t implements the interface type iface
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
@@ -0,0 +1,286 @@
+package mysql
+
+import (
+	"database/sql"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type metadataPerson struct {
+	Id      int       `db:"column=id primarykey=yes table=Users"`
+	Name    string    `db:"column=name"`
+	Dtadded time.Time `db:"column=dtadded omit=yes"`
+	Status  *int      `db:"column=status"`
+	Blob    []byte    `db:"column=blob"`
+	hidden  int
+}
+
+func TestGetStructInfo(t *testing.T) {
+	info := getStructInfo(reflect.TypeOf(metadataPerson{}))
+	assert.Equal(t, "Users", info.table)
+	assert.NoError(t, info.err)
+	assert.Len(t, info.primaryKeys, 1)
+	assert.Equal(t, "id", info.primaryKeys[0].column)
+	assert.Len(t, info.fields, 6)
+	assert.Len(t, info.columns, 5)
+
+	assert.True(t, info.columns["dtadded"].omit)
+	assert.Equal(t, "Time", info.columns["dtadded"].typeName)
+	assert.Equal(t, "*int", info.columns["status"].typeName)
+	assert.Equal(t, reflect.Pointer, info.columns["status"].kind)
+	assert.Equal(t, "[]uint8", info.columns["blob"].typeName)
+	assert.Equal(t, []int{1}, info.columns["name"].index)
+	assert.NotContains(t, info.columns, "")
+
+	// the same pointer is handed out on every lookup
+	assert.Same(t, info, getStructInfo(reflect.TypeOf(metadataPerson{})))
+}
+
+func TestGetStructInfoMissingColumn(t *testing.T) {
+	type testType struct {
+		Id   int `db:"column=id primarykey=yes table=Users"`
+		Name string
+		Note string
+	}
+	info := getStructInfo(reflect.TypeOf(testType{}))
+	assert.EqualError(t, info.err, "no column name specified for field Name")
+	assert.Contains(t, info.columns, "id")
+}
+
+type audit struct {
+	Dtadded   time.Time  `db:"column=dtadded autocreate=yes"`
+	Dtupdated *time.Time `db:"column=dtupdated autoupdate=yes"`
+}
+
+type address struct {
+	City     string `db:"column=city"`
+	Postcode string `db:"column=postcode"`
+}
+
+type nestedPerson struct {
+	Id      int     `db:"column=id primarykey=yes table=Users"`
+	Name    string  `db:"column=name"`
+	Address address `db:"prefix=addr_"`
+	audit
+}
+
+func TestGetStructInfoNested(t *testing.T) {
+	info := getStructInfo(reflect.TypeOf(nestedPerson{}))
+	assert.NoError(t, info.err)
+	assert.Len(t, info.fields, 6)
+	assert.Equal(t, []int{2, 1}, info.columns["addr_postcode"].index)
+	assert.Equal(t, []int{3, 0}, info.columns["dtadded"].index)
+	assert.True(t, info.columns["dtupdated"].autoUpdate)
+
+	// a nested struct needs a prefix= to be flattened
+	type unprefixed struct {
+		Id      int `db:"column=id primarykey=yes table=Users"`
+		Address address
+	}
+	assert.EqualError(t, getStructInfo(reflect.TypeOf(unprefixed{})).err, "no column name specified for field Address")
+}
+
+type baseName struct {
+	Name string `db:"column=name"`
+}
+
+type otherName struct {
+	Name string `db:"column=name"`
+}
+
+func TestGetStructInfoShadowed(t *testing.T) {
+	type shadowing struct {
+		baseName
+		Id   int    `db:"column=id primarykey=yes table=Users"`
+		Name string `db:"column=name"`
+	}
+	info := getStructInfo(reflect.TypeOf(shadowing{}))
+	assert.NoError(t, info.err)
+	assert.Len(t, info.fields, 2)
+	assert.Equal(t, []int{2}, info.columns["name"].index)
+
+	db, mock := setupMockDatabase(t)
+	sql, args, err := db.InsertArgs(shadowing{baseName{"Inner"}, 0, "Outer"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name) VALUES (?);", sql)
+	assert.Equal(t, []any{"Outer"}, args)
+
+	mock.ExpectQuery("SELECT * FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test"))
+	rows, err := QueryStructWith[shadowing](db, "SELECT * FROM Users")
+	assert.NoError(t, err)
+	assert.Equal(t, []shadowing{{Id: 1, Name: "Test"}}, rows)
+
+	// two embedded columns at the same depth conflict, unless a shallower field shadows both
+	type conflicting struct {
+		baseName
+		otherName
+		Id int `db:"column=id primarykey=yes table=Users"`
+	}
+	_, _, err = db.InsertArgs(conflicting{Id: 1})
+	assert.EqualError(t, err, "column name is mapped by both Name and Name")
+
+	type resolved struct {
+		baseName
+		otherName
+		Id   int    `db:"column=id primarykey=yes table=Users"`
+		Name string `db:"column=name"`
+	}
+	assert.NoError(t, getStructInfo(reflect.TypeOf(resolved{})).err)
+}
+
+func TestNestedWriteAndRead(t *testing.T) {
+	db, mock, now := stampedDatabase(t)
+
+	person := nestedPerson{Id: 1, Name: "Test", Address: address{"Wellington", "6011"}}
+	sql, args, err := db.InsertArgs(person)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,addr_city,addr_postcode,dtadded,dtupdated) VALUES (?,?,?,?,?);", sql)
+	assert.Equal(t, []any{"Test", "Wellington", "6011", now, now}, args)
+
+	sql, args, err = db.UpdateArgs(person)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=?,addr_city=?,addr_postcode=?,dtupdated=? WHERE id=?;", sql)
+	assert.Equal(t, []any{"Test", "Wellington", "6011", now, 1}, args)
+
+	added := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("SELECT * FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "addr_city", "addr_postcode", "dtadded"}).
+			AddRow(1, "Test", "Wellington", "6011", added))
+	people, err := QueryStructWith[nestedPerson](db, "SELECT * FROM Users")
+	assert.NoError(t, err)
+	if assert.Len(t, people, 1) {
+		assert.Equal(t, address{"Wellington", "6011"}, people[0].Address)
+		assert.True(t, added.Equal(people[0].Dtadded))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Audit is audit exported, whose embedded field is exported too
+type Audit audit
+
+func TestEmbeddedPointer(t *testing.T) {
+	type unexportedEmbed struct {
+		Id int `db:"column=id primarykey=yes table=Users"`
+		*audit
+	}
+	type exportedEmbed struct {
+		Id int `db:"column=id primarykey=yes table=Users"`
+		*Audit
+	}
+	db, mock := setupMockDatabase(t)
+
+	_, _, err := db.InsertArgs(unexportedEmbed{Id: 1})
+	assert.EqualError(t, err, "embedded pointer struct audit is not supported, embed it by value")
+	_, _, err = db.InsertArgs(exportedEmbed{Id: 1, Audit: &Audit{}})
+	assert.EqualError(t, err, "embedded pointer struct Audit is not supported, embed it by value")
+
+	mock.ExpectQuery("SELECT * FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "dtadded"}).AddRow(1, time.Now()))
+	_, err = QueryStructWith[unexportedEmbed](db, "SELECT * FROM Users")
+	assert.EqualError(t, err, "embedded pointer struct audit is not supported, embed it by value")
+
+	mock.ExpectQuery("SELECT * FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "dtadded"}).AddRow(1, time.Now()))
+	_, err = QueryStructWith[exportedEmbed](db, "SELECT * FROM Users")
+	assert.EqualError(t, err, "embedded pointer struct Audit is not supported, embed it by value")
+
+	// a pointer read as one value is still a column
+	type scannedEmbed struct {
+		Id int `db:"column=id primarykey=yes table=Users"`
+		*sql.NullString
+	}
+	assert.NoError(t, getStructInfo(reflect.TypeOf(scannedEmbed{})).unsupported)
+}
+
+func TestGetStructInfoConcurrent(t *testing.T) {
+	type concurrentType struct {
+		Id int `db:"column=id primarykey=yes table=Users"`
+	}
+	var wg sync.WaitGroup
+	infos := make([]*structInfo, 16)
+	for i := range infos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			infos[i] = getStructInfo(reflect.TypeOf(concurrentType{}))
+		}(i)
+	}
+	wg.Wait()
+	for _, info := range infos {
+		assert.Same(t, infos[0], info)
+	}
+}
+
+// legacyStructDetails is the per-column lookup QueryStruct used before the metadata cache, kept to benchmark against
+func legacyStructDetails[T any](dbFieldName string) (string, string) {
+	var st T
+	t := reflect.TypeOf(st)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if decodeTag(field.Tag.Get("db"))["column"] == dbFieldName {
+			return field.Name, fieldTypeName(field.Type)
+		}
+	}
+	return "", ""
+}
+
+var benchmarkColumns = []string{"id", "name", "dtadded", "status", "blob"}
+
+// BenchmarkColumnLookup compares resolving the columns of a 10k row result with and without the cache
+func BenchmarkColumnLookup(b *testing.B) {
+	b.Run("Uncached", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for row := 0; row < 10000; row++ {
+				for _, column := range benchmarkColumns {
+					legacyStructDetails[metadataPerson](column)
+				}
+			}
+		}
+	})
+	b.Run("Cached", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for row := 0; row < 10000; row++ {
+				info := getStructInfo(reflect.TypeOf(metadataPerson{}))
+				for _, column := range benchmarkColumns {
+					_ = info.columns[column]
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkQueryStruct10kRows(b *testing.B) {
+	type benchmarkPerson struct {
+		Id     int    `db:"column=id primarykey=yes table=Users"`
+		Name   string `db:"column=name"`
+		Status int    `db:"column=status"`
+	}
+	db := NewDatabase("test/test", nil)
+	conn, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		b.Fatal(err)
+	}
+	db.dbConnection = conn
+	db.connected = true
+
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		rows := sqlmock.NewRows([]string{"id", "name", "status"})
+		for i := 0; i < 10000; i++ {
+			rows.AddRow(i, "Test", 1)
+		}
+		mock.ExpectQuery("SELECT id,name,status FROM Users").WillReturnRows(rows)
+		b.StartTimer()
+
+		people, err := QueryStructWith[benchmarkPerson](db, "SELECT id,name,status FROM Users")
+		if err != nil || len(people) != 10000 {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Named parameters are written as :name or @name and bound from a map[string]any, or from a struct whose fields are
+// matched on their db column tags. Placeholders inside quoted strings, quoted identifiers and comments are left
+// alone, as are @@system variables. A MySQL @user variable has to be passed as a parameter or written with ? instead.
+
+// ExecuteNamed runs a statement with named parameters, see ExecuteContext
+func (db *Database) ExecuteNamed(sql string, params any) (int64, int64, error) {
+	return db.ExecuteNamedContext(context.Background(), sql, params)
+}
+
+// ExecuteNamedContext is ExecuteNamed with the statement run under ctx
+func (db *Database) ExecuteNamedContext(ctx context.Context, sql string, params any) (int64, int64, error) {
+	return executeNamed(ctx, db, sql, params)
+}
+
+// QueryNamed runs a query with named parameters, see QueryContext
+func (db *Database) QueryNamed(sql string, params any) ([]Record, error) {
+	return db.QueryNamedContext(context.Background(), sql, params)
+}
+
+// QueryNamedContext is QueryNamed with the query run under ctx
+func (db *Database) QueryNamedContext(ctx context.Context, sql string, params any) ([]Record, error) {
+	return queryNamed(ctx, db, sql, params)
+}
+
+// ExecuteNamed runs a statement with named parameters inside the transaction
+func (t *Tx) ExecuteNamed(sql string, params any) (int64, int64, error) {
+	return t.ExecuteNamedContext(context.Background(), sql, params)
+}
+
+// ExecuteNamedContext is ExecuteNamed with the statement run under ctx
+func (t *Tx) ExecuteNamedContext(ctx context.Context, sql string, params any) (int64, int64, error) {
+	return executeNamed(ctx, t, sql, params)
+}
+
+// QueryNamed runs a query with named parameters inside the transaction
+func (t *Tx) QueryNamed(sql string, params any) ([]Record, error) {
+	return t.QueryNamedContext(context.Background(), sql, params)
+}
+
+// QueryNamedContext is QueryNamed with the query run under ctx
+func (t *Tx) QueryNamedContext(ctx context.Context, sql string, params any) ([]Record, error) {
+	return queryNamed(ctx, t, sql, params)
+}
+
+// QueryStructNamed runs the query with named parameters against the package-level DB and maps each row into a T
+func QueryStructNamed[T any](sql string, params any) ([]T, error) {
+	return QueryStructNamedWith[T](DB, sql, params)
+}
+
+// QueryStructNamedWith is QueryStructNamed against the given database or transaction
+func QueryStructNamedWith[T any](h Handle, sql string, params any) ([]T, error) {
+	return QueryStructNamedContext[T](context.Background(), h, sql, params)
+}
+
+// QueryStructNamedContext is QueryStructNamed against the given handle under ctx
+func QueryStructNamedContext[T any](ctx context.Context, h Handle, sql string, params any) ([]T, error) {
+	query, args, err := h.database().bindNamed(sql, params)
+	if err != nil {
+		return make([]T, 0), err
+	}
+	return QueryStructContext[T](ctx, h, query, args...)
+}
+
+func executeNamed(ctx context.Context, h Handle, sql string, params any) (int64, int64, error) {
+	query, args, err := h.database().bindNamed(sql, params)
+	if err != nil {
+		return 0, 0, err
+	}
+	return h.ExecuteContext(ctx, query, args...)
+}
+
+func queryNamed(ctx context.Context, h Handle, sql string, params any) ([]Record, error) {
+	query, args, err := h.database().bindNamed(sql, params)
+	if err != nil {
+		return make([]Record, 0), err
+	}
+	return h.QueryContext(ctx, query, args...)
+}
+
+// bindNamed rewrites the named placeholders in sql to ?, returning the values to bind to them in order
+func (db *Database) bindNamed(sql string, params any) (string, []any, error) {
+	values, err := db.namedValues(params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var args []any
+	query, err := rewritePlaceholders(sql, namedPlaceholderEnd, func(placeholder string) (string, error) {
+		value, ok := values[placeholder[1:]]
+		if !ok {
+			return "", fmt.Errorf("%w %s", ErrMissingParameter, placeholder)
+		}
+		args = append(args, value)
+		return "?", nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
+
+// namedPlaceholderEnd returns the end of the :name or @name placeholder starting at i, or i when there is none. A
+// name directly after another name, or a doubled prefix as in @@time_zone, isn't a placeholder.
+func namedPlaceholderEnd(sql string, i int) int {
+	c := sql[i]
+	if (c != ':' && c != '@') || i+1 >= len(sql) || !isNameStart(sql[i+1]) {
+		return i
+	}
+	if i > 0 && (sql[i-1] == c || isNameByte(sql[i-1])) {
+		return i
+	}
+	end := i + 1
+	for end < len(sql) && isNameByte(sql[end]) {
+		end++
+	}
+	return end
+}
+
+// namedValues collects the named parameters from a map with string keys or a struct, keyed by column name
+func (db *Database) namedValues(params any) (map[string]any, error) {
+	values := make(map[string]any)
+	if params == nil {
+		return values, nil
+	}
+	if m, ok := params.(map[string]any); ok {
+		return m, nil
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(params))
+	switch {
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		iter := v.MapRange()
+		for iter.Next() {
+			values[iter.Key().String()] = iter.Value().Interface()
+		}
+	case v.Kind() == reflect.Struct:
+		for _, fi := range db.structInfo(v.Type()).fields {
+			if !fi.exported || fi.column == "" {
+				continue
+			}
+			value, err := fieldValue(fi, v.FieldByIndex(fi.index))
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", fi.column, err)
+			}
+			values[fi.column] = value
+		}
+	default:
+		return nil, fmt.Errorf("named parameters must be a map or a struct, got %T", params)
+	}
+	return values, nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameByte(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
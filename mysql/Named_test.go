@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindNamed(t *testing.T) {
+	query, args, err := DB.bindNamed("SELECT * FROM Users WHERE id=:id AND (name=@name OR alias=:name)", map[string]any{"id": 1, "name": "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Users WHERE id=? AND (name=? OR alias=?)", query)
+	assert.Equal(t, []any{1, "Test", "Test"}, args)
+
+	// literals, quoted identifiers, comments and system variables are left alone
+	sql := "SELECT ':id', \"@id\", 'it''s :id', 'a\\':id', `:id` -- :id\n" +
+		"FROM Users # @id\n" +
+		"/* :id */ WHERE id=:id AND tz=@@time_zone AND dt > '12:30:00'"
+	query, args, err = DB.bindNamed(sql, map[string]any{"id": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT ':id', \"@id\", 'it''s :id', 'a\\':id', `:id` -- :id\n"+
+		"FROM Users # @id\n"+
+		"/* :id */ WHERE id=? AND tz=@@time_zone AND dt > '12:30:00'", query)
+	assert.Equal(t, []any{1}, args)
+
+	_, _, err = DB.bindNamed("SELECT * FROM Users WHERE id=:id AND name=:name", map[string]any{"id": 1})
+	assert.ErrorIs(t, err, ErrMissingParameter)
+	assert.EqualError(t, err, "missing named parameter :name")
+
+	_, _, err = DB.bindNamed("SELECT * FROM Users WHERE id=:id", 1)
+	assert.EqualError(t, err, "named parameters must be a map or a struct, got int")
+}
+
+func TestBindNamedStruct(t *testing.T) {
+	type params struct {
+		Id     int    `db:"column=id primarykey=yes table=Users"`
+		Name   string `db:"column=name"`
+		Status cents  `db:"column=status"`
+	}
+	query, args, err := DB.bindNamed("UPDATE Users SET name=:name, status=:status WHERE id=:id", &params{1, "Test", 150})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=?, status=? WHERE id=?", query)
+	assert.Equal(t, []any{"Test", "1.50", 1}, args)
+
+	query, args, err = DB.bindNamed("SELECT * FROM Users WHERE id=:id", map[string]int{"id": 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Users WHERE id=?", query)
+	assert.Equal(t, []any{2}, args)
+}
+
+func TestExecuteNamed(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("UPDATE Users SET name=? WHERE id=?").
+		WithArgs("Test", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, rowsAffected, err := db.ExecuteNamed("UPDATE Users SET name=:name WHERE id=:id", map[string]any{"id": 1, "name": "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	_, _, err = db.ExecuteNamed("UPDATE Users SET name=:name WHERE id=:id", map[string]any{"id": 1})
+	assert.ErrorIs(t, err, ErrMissingParameter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryStructNamed(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users WHERE name=? AND id>?").
+		WithArgs("Test", 0).
+		WillReturnRows(cursorRows())
+
+	rows, err := QueryStructNamedWith[cursorPerson](db, "SELECT id,name FROM Users WHERE name=:name AND id>:id", cursorPerson{Name: "Test"})
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy derives column names from field names, and table names from type names, for structs that leave out
+// the column= and table= tags. Set one on Database.Naming to opt in.
+type NamingStrategy struct {
+	name func(string) string
+}
+
+var (
+	// SnakeCaseNaming maps UserID to user_id
+	SnakeCaseNaming = &NamingStrategy{snakeCase}
+	// LowerCaseNaming maps UserID to userid
+	LowerCaseNaming = &NamingStrategy{strings.ToLower}
+	// ExactNaming uses the Go name as it is
+	ExactNaming = &NamingStrategy{func(name string) string { return name }}
+)
+
+// NamingFunc returns a strategy naming columns and tables with fn
+func NamingFunc(fn func(string) string) *NamingStrategy {
+	return &NamingStrategy{fn}
+}
+
+// tableNamer is implemented by structs naming their own table
+type tableNamer interface {
+	TableName() string
+}
+
+// inferTable returns the table of a struct type without a table= tag: the result of its TableName method when it has
+// one, else its type name under the naming strategy
+func inferTable(t reflect.Type, naming *NamingStrategy) string {
+	if namer, ok := reflect.New(t).Interface().(tableNamer); ok {
+		return namer.TableName()
+	}
+	if naming == nil || t.Name() == "" {
+		return ""
+	}
+	return naming.name(t.Name())
+}
+
+// snakeCase lower cases name with an underscore at each word boundary, keeping acronyms together, e.g. HTTPServerID
+// becomes http_server_id
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || unicode.IsUpper(previous) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
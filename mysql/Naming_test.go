@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type UserAccount struct {
+	ID        int `db:"primarykey=yes"`
+	FirstName string
+	HTTPPort  int    `db:"column=port"`
+	Secret    string `db:"-"`
+}
+
+type namedTable struct {
+	Id   int    `db:"column=id primarykey=yes"`
+	Name string `db:"column=name"`
+}
+
+func (namedTable) TableName() string { return "People" }
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Id":           "id",
+		"ID":           "id",
+		"UserID":       "user_id",
+		"FirstName":    "first_name",
+		"HTTPServerID": "http_server_id",
+		"Address2":     "address2",
+		"UserAccount":  "user_account",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, snakeCase(name), name)
+	}
+}
+
+func TestNamingStrategy(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+
+	// without a strategy the tags stay required
+	_, _, err := db.InsertArgs(UserAccount{FirstName: "Test"})
+	assert.EqualError(t, err, "no column name specified for field ID")
+
+	db.Naming = SnakeCaseNaming
+	sql, args, err := db.InsertArgs(UserAccount{FirstName: "Test", HTTPPort: 80, Secret: "hidden"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO user_account(first_name,port) VALUES (?,?);", sql)
+	assert.Equal(t, []any{"Test", 80}, args)
+
+	sql, args, err = db.UpdateArgs(UserAccount{ID: 1, FirstName: "Test", HTTPPort: 80})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE user_account SET first_name=?,port=? WHERE id=?;", sql)
+	assert.Equal(t, []any{"Test", 80, 1}, args)
+
+	db.Naming = LowerCaseNaming
+	sql, _, err = db.InsertArgs(UserAccount{FirstName: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO useraccount(firstname,port) VALUES (?,?);", sql)
+
+	db.Naming = ExactNaming
+	sql, _, err = db.Delete(UserAccount{ID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM UserAccount WHERE ID=?;", sql)
+
+	db.Naming = NamingFunc(strings.ToUpper)
+	mock.ExpectQuery("SELECT * FROM USERACCOUNT").
+		WillReturnRows(mock.NewRows([]string{"ID", "FIRSTNAME", "port"}).AddRow(1, "Test", 80))
+	accounts, err := QueryStructWith[UserAccount](db, "SELECT * FROM USERACCOUNT")
+	assert.NoError(t, err)
+	assert.Equal(t, []UserAccount{{1, "Test", 80, ""}}, accounts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTableName(t *testing.T) {
+	db, _ := setupMockDatabase(t)
+
+	// TableName is used even without a strategy, the type name only with one
+	sql, _, err := db.Delete(namedTable{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM People WHERE id=?;", sql)
+	assert.Equal(t, "", getStructInfo(reflect.TypeOf(UserAccount{})).table)
+
+	// TableName takes precedence over the type name
+	db.Naming = SnakeCaseNaming
+	sql, args, err := db.InsertArgs(namedTable{Name: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO People(name) VALUES (?);", sql)
+	assert.Equal(t, []any{"Test"}, args)
+}
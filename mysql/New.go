@@ -1,82 +1,72 @@
 package mysql
 
 import (
-    "database/sql"
-    "errors"
-    "sync"
-    "time"
-    
-    "log/slog"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Database struct {
-    dbConnection               *sql.DB
-    DSN                        string
-    Logger                     *slog.Logger
-    ShowSQL                    bool
-    Timed                      bool
-    Lock                       sync.Mutex
-    connected                  bool
-    MaxDatabaseOpenConnections int
-    MaxDatabaseIdleConnections int
-    DatabaseIdleTimeout        time.Duration
+	dbConnection               *sql.DB
+	DSN                        string
+	Logger                     *slog.Logger
+	ShowSQL                    bool
+	Timed                      bool
+	Lock                       sync.Mutex
+	connected                  bool
+	driverName                 string
+	MaxDatabaseOpenConnections int
+	MaxDatabaseIdleConnections int
+	DatabaseIdleTimeout        time.Duration
+	DatabaseMaxLifetime        time.Duration
+	ConnectRetries             int
+	ConnectRetryBackoff        time.Duration
+	// EmptySliceAsFalse binds an empty slice as an empty subquery, so "id IN (?)" matches nothing, instead of failing
+	// with ErrEmptySlice
+	EmptySliceAsFalse bool
+	// Clock returns the time written to autocreate=yes and autoupdate=yes columns, time.Now when nil. The times are
+	// written in the time zone of the loc parameter of the DSN, UTC by default.
+	Clock func() time.Time
+	// Naming names the columns of fields without a column= tag, and the tables of structs without a table= tag,
+	// instead of rejecting them. Nil, the default, requires the tags.
+	Naming *NamingStrategy
+	// loc caches the loc parameter parsed from the DSN, see location
+	loc atomic.Pointer[dsnLocation]
 }
 
+// DB is the package-level default handle. It is set by New and used by the package-level generic helpers
+// (QueryStruct, QuerySingleStruct, InsertMany) that don't take a handle.
 var DB *Database
 
-func New(newDSN string, L *slog.Logger) {
-    
-    DB = &Database{
-        connected: false,
-        DSN:       newDSN,
-        Logger:    L,
-        ShowSQL:   false,
-    }
+// NewDatabase creates a handle with its own connection pool without touching the package-level DB,
+// so several databases can be used side by side.
+func NewDatabase(newDSN string, L *slog.Logger) *Database {
+	return &Database{
+		connected:                  false,
+		driverName:                 "mysql",
+		DSN:                        newDSN,
+		Logger:                     L,
+		ShowSQL:                    false,
+		MaxDatabaseOpenConnections: DefaultMaxDatabaseOpenConnections,
+		MaxDatabaseIdleConnections: DefaultMaxDatabaseIdleConnections,
+		DatabaseIdleTimeout:        DefaultDatabaseIdleTimeout,
+		ConnectRetries:             DefaultConnectRetries,
+		ConnectRetryBackoff:        DefaultConnectRetryBackoff,
+	}
 }
 
-func getConnection() (*sql.DB, error) {
-    
-    DB.Lock.Lock()
-    // check once more - in case a prev goroutine has established a connection
-    if DB.connected && DB.dbConnection != nil {
-        DB.Lock.Unlock()
-        return DB.dbConnection, nil
-    }
-    
-    if DB.DSN == "" {
-        return nil, errors.New("empty database dsn")
-    }
-    
-    var err error
-    
-    // attempt 3 times to connect, then give up
-    for i := 0; i < 3; i++ {
-        DB.dbConnection, err = sql.Open("mysql", DB.DSN)
-        
-        if err == nil {
-            // Open may just validate its arguments without creating a connection to the database.
-            // To verify that the data source name is valid, call Ping.
-            err = DB.dbConnection.Ping()
-            if err == nil {
-                break // connection was fine
-            }
-            DB.Logger.With("attempt", i).With("error", err.Error()).Error("Unable to Ping Database")
-            time.Sleep(500 * time.Millisecond) // wait a short while before trying again
-            continue
-        }
-        time.Sleep(500 * time.Millisecond)
-        DB.Logger.With("attempt", i).With("error", err.Error()).Error("Unable to Ping Database")
-    }
-    
-    if err != nil {
-        return nil, err
-    }
-    
-    DB.dbConnection.SetMaxOpenConns(25)
-    DB.dbConnection.SetMaxIdleConns(25)
-    DB.dbConnection.SetConnMaxIdleTime(5 * time.Minute)
-    DB.connected = true
-    DB.Lock.Unlock()
-    
-    return DB.dbConnection, nil
+// New creates a handle, installs it as the package-level DB and returns it.
+func New(newDSN string, L *slog.Logger) *Database {
+	DB = NewDatabase(newDSN, L)
+	return DB
+}
+
+// logger returns the handle's logger, falling back to the slog default when none was given.
+func (db *Database) logger() *slog.Logger {
+	if db == nil || db.Logger == nil {
+		return slog.Default()
+	}
+	return db.Logger
 }
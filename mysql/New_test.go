@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupMockDatabase creates a standalone handle backed by its own sqlmock pool
+func setupMockDatabase(t *testing.T) (*Database, sqlmock.Sqlmock) {
+	db := NewDatabase("test/test", slog.Default())
+	conn, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	db.dbConnection = conn
+	db.connected = true
+	return db, mock
+}
+
+func TestNewSetsGlobal(t *testing.T) {
+	db := New("test/test", nil)
+	assert.Same(t, DB, db)
+
+	other := NewDatabase("other/other", nil)
+	assert.Same(t, DB, db)
+	assert.NotSame(t, db, other)
+}
+
+// TestHandlesUseOwnPool checks that each handle executes against its own pool, not the global one
+func TestHandlesUseOwnPool(t *testing.T) {
+	New("test/test", slog.Default())
+	DB.dbConnection, _, _ = sqlmock.New()
+	DB.connected = true
+
+	first, firstMock := setupMockDatabase(t)
+	second, secondMock := setupMockDatabase(t)
+
+	firstMock.ExpectExec("DELETE FROM a").WillReturnResult(sqlmock.NewResult(0, 1))
+	secondMock.ExpectExec("DELETE FROM b").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	_, rowsAffected, err := first.Execute("DELETE FROM a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	_, rowsAffected, err = second.Execute("DELETE FROM b")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rowsAffected)
+
+	assert.NoError(t, firstMock.ExpectationsWereMet())
+	assert.NoError(t, secondMock.ExpectationsWereMet())
+}
+
+func TestQueryStructWith(t *testing.T) {
+	type Person struct {
+		Id   int    `db:"column=id primarykey=yes table=Users"`
+		Name string `db:"column=name"`
+	}
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test").AddRow(2, "Other"))
+
+	people, err := QueryStructWith[Person](db, "SELECT id,name FROM Users")
+	assert.NoError(t, err)
+	assert.Equal(t, []Person{{1, "Test"}, {2, "Other"}}, people)
+
+	mock.ExpectQuery("SELECT id,name FROM Users WHERE id=?").WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Other"))
+
+	person, err := QuerySingleStructWith[Person](db, "SELECT id,name FROM Users WHERE id=?", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, Person{2, "Other"}, person)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,142 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrEmptySlice is returned when a slice bound to a ? placeholder has no elements and the handle doesn't rewrite
+// empty slices, see Database.EmptySliceAsFalse
+var ErrEmptySlice = errors.New("empty slice bound to placeholder")
+
+// emptySliceSql replaces the placeholder of an empty slice. An empty subquery makes IN false and NOT IN true.
+const emptySliceSql = "SELECT NULL FROM DUAL WHERE FALSE"
+
+// expandSlices expands each ? bound to a slice into one placeholder per element, so "id IN (?)" can be given a
+// []int. Byte slices and types with a driver.Valuer or a registered converter are bound as a single value.
+func expandSlices(sql string, args []any, emptyAsFalse bool) (string, []any, error) {
+	expand := false
+	for _, arg := range args {
+		if isExpandable(arg) {
+			expand = true
+			break
+		}
+	}
+	if !expand {
+		return sql, args, nil
+	}
+
+	expanded := make([]any, 0, len(args))
+	next := 0
+	query, err := rewritePlaceholders(sql, questionMarkEnd, func(placeholder string) (string, error) {
+		if next >= len(args) {
+			return placeholder, nil
+		}
+		arg := args[next]
+		next++
+		if !isExpandable(arg) {
+			expanded = append(expanded, arg)
+			return placeholder, nil
+		}
+
+		rv := reflect.ValueOf(arg)
+		if rv.Len() == 0 {
+			if emptyAsFalse {
+				return emptySliceSql, nil
+			}
+			return "", fmt.Errorf("%w: parameter %d", ErrEmptySlice, next)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			expanded = append(expanded, rv.Index(i).Interface())
+		}
+		return strings.TrimSuffix(strings.Repeat("?,", rv.Len()), ","), nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return query, append(expanded, args[next:]...), nil
+}
+
+// isExpandable reports whether arg is a slice to expand into a placeholder list
+func isExpandable(arg any) bool {
+	t := reflect.TypeOf(arg)
+	if t == nil || t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+	if t.Implements(valuerType) || lookupConverter(t) != nil {
+		return false
+	}
+	return true
+}
+
+// questionMarkEnd returns the end of the ? placeholder at i, or i when there is none
+func questionMarkEnd(sql string, i int) int {
+	if sql[i] == '?' {
+		return i + 1
+	}
+	return i
+}
+
+// rewritePlaceholders copies sql, replacing each placeholder outside quoted strings, quoted identifiers and comments
+// with what replace returns for it. placeholderEnd returns the end of the placeholder starting at i, or i when there
+// is none.
+func rewritePlaceholders(sql string, placeholderEnd func(sql string, i int) int, replace func(placeholder string) (string, error)) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		end := i + 1
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end = quotedEnd(sql, i)
+		case c == '#' || strings.HasPrefix(sql[i:], "-- ") || strings.HasPrefix(sql[i:], "--\t") || strings.HasPrefix(sql[i:], "--\n"):
+			end = commentEnd(sql, i+1, "\n")
+		case strings.HasPrefix(sql[i:], "/*"):
+			end = commentEnd(sql, i+2, "*/")
+		default:
+			if stop := placeholderEnd(sql, i); stop > i {
+				replacement, err := replace(sql[i:stop])
+				if err != nil {
+					return "", err
+				}
+				out.WriteString(replacement)
+				i = stop
+				continue
+			}
+		}
+		out.WriteString(sql[i:end])
+		i = end
+	}
+	return out.String(), nil
+}
+
+// quotedEnd returns the index just past the quoted string or identifier starting at start. Doubled quotes, and
+// backslash escapes in strings, don't end it.
+func quotedEnd(sql string, start int) int {
+	quote := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(sql)
+}
+
+// commentEnd returns the index just past the first terminator at or after from, or the end of sql
+func commentEnd(sql string, from int, terminator string) int {
+	end := strings.Index(sql[from:], terminator)
+	if end < 0 {
+		return len(sql)
+	}
+	return from + end + len(terminator)
+}
@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandSlices(t *testing.T) {
+	query, args, err := expandSlices("SELECT * FROM Users WHERE id IN (?) AND name=? AND status IN (?)",
+		[]any{[]int{1, 2, 3}, "Test", []string{"a", "b"}}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Users WHERE id IN (?,?,?) AND name=? AND status IN (?,?)", query)
+	assert.Equal(t, []any{1, 2, 3, "Test", "a", "b"}, args)
+
+	// byte slices and Valuers are single values, and ? inside literals isn't a placeholder
+	raw := json.RawMessage(`{}`)
+	query, args, err = expandSlices("SELECT '?' FROM Users WHERE data=? AND id IN (?) AND doc=?",
+		[]any{[]byte("x"), []int64{7}, raw}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT '?' FROM Users WHERE data=? AND id IN (?) AND doc=?", query)
+	assert.Equal(t, []any{[]byte("x"), int64(7), raw}, args)
+
+	_, _, err = expandSlices("SELECT * FROM Users WHERE id IN (?)", []any{[]int{}}, false)
+	assert.ErrorIs(t, err, ErrEmptySlice)
+
+	query, args, err = expandSlices("SELECT * FROM Users WHERE id NOT IN (?) AND name=?", []any{[]int{}, "Test"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Users WHERE id NOT IN (SELECT NULL FROM DUAL WHERE FALSE) AND name=?", query)
+	assert.Equal(t, []any{"Test"}, args)
+}
+
+func TestQuerySliceParameter(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users WHERE id IN (?,?,?)").
+		WithArgs(1, 2, 3).
+		WillReturnRows(cursorRows())
+	mock.ExpectExec("DELETE FROM Users WHERE id IN (?,?)").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery("SELECT id,name FROM Users WHERE name IN (?,?)").
+		WithArgs("Test", "Other").
+		WillReturnRows(cursorRows())
+
+	rows, err := QueryStructWith[cursorPerson](db, "SELECT id,name FROM Users WHERE id IN (?)", []int{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+
+	_, rowsAffected, err := db.Execute("DELETE FROM Users WHERE id IN (?)", []int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rowsAffected)
+
+	records, err := db.QueryNamed("SELECT id,name FROM Users WHERE name IN (:names)", map[string]any{"names": []string{"Test", "Other"}})
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	_, err = db.Query("SELECT id,name FROM Users WHERE id IN (?)", []int{})
+	assert.ErrorIs(t, err, ErrEmptySlice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,85 @@
+package mysql
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Defaults applied by NewDatabase, matching the values the pool used to be hard-coded to.
+const (
+	DefaultMaxDatabaseOpenConnections = 25
+	DefaultMaxDatabaseIdleConnections = 25
+	DefaultDatabaseIdleTimeout        = 5 * time.Minute
+	DefaultConnectRetries             = 3
+	DefaultConnectRetryBackoff        = 500 * time.Millisecond
+	// MaxConnectRetryDelay caps the wait between two connect attempts
+	MaxConnectRetryDelay = 30 * time.Second
+)
+
+// PoolConfig is the connection pool configuration of a Database. A zero MaxLifetime or IdleTimeout means
+// connections are not closed for their age or idleness, as in database/sql.
+type PoolConfig struct {
+	MaxOpenConnections int
+	MaxIdleConnections int
+	IdleTimeout        time.Duration
+	MaxLifetime        time.Duration
+	// ConnectRetries is the number of attempts made to open and ping the database before giving up
+	ConnectRetries int
+	// ConnectRetryBackoff is the wait after the first failed attempt, doubled after each further one up to
+	// MaxConnectRetryDelay
+	ConnectRetryBackoff time.Duration
+}
+
+// PoolConfig returns the current pool configuration
+func (db *Database) PoolConfig() PoolConfig {
+	db.Lock.Lock()
+	defer db.Lock.Unlock()
+	return db.poolConfig()
+}
+
+// SetPoolConfig changes the pool configuration. When the database is already connected the new limits are applied to
+// the live pool straight away, otherwise they are applied when it connects.
+func (db *Database) SetPoolConfig(config PoolConfig) {
+	db.Lock.Lock()
+	defer db.Lock.Unlock()
+
+	db.MaxDatabaseOpenConnections = config.MaxOpenConnections
+	db.MaxDatabaseIdleConnections = config.MaxIdleConnections
+	db.DatabaseIdleTimeout = config.IdleTimeout
+	db.DatabaseMaxLifetime = config.MaxLifetime
+	db.ConnectRetries = config.ConnectRetries
+	db.ConnectRetryBackoff = config.ConnectRetryBackoff
+
+	if db.dbConnection != nil {
+		applyPoolConfig(db.dbConnection, config)
+	}
+}
+
+// poolConfig collects the pool fields of the Database, callers must hold db.Lock
+func (db *Database) poolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConnections:  db.MaxDatabaseOpenConnections,
+		MaxIdleConnections:  db.MaxDatabaseIdleConnections,
+		IdleTimeout:         db.DatabaseIdleTimeout,
+		MaxLifetime:         db.DatabaseMaxLifetime,
+		ConnectRetries:      db.ConnectRetries,
+		ConnectRetryBackoff: db.ConnectRetryBackoff,
+	}
+}
+
+// applyPoolConfig sets the limits of the configuration on an open pool
+func applyPoolConfig(pool *sql.DB, config PoolConfig) {
+	pool.SetMaxOpenConns(config.MaxOpenConnections)
+	pool.SetMaxIdleConns(config.MaxIdleConnections)
+	pool.SetConnMaxIdleTime(config.IdleTimeout)
+	pool.SetConnMaxLifetime(config.MaxLifetime)
+}
+
+// retryDelay returns how long to wait after the given (zero based) failed connect attempt
+func (config PoolConfig) retryDelay(attempt int) time.Duration {
+	delay := config.ConnectRetryBackoff
+	for i := 0; i < attempt && delay < MaxConnectRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxConnectRetryDelay)
+}
@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolConfigDefaults(t *testing.T) {
+	db := NewDatabase("test/test", nil)
+	assert.Equal(t, PoolConfig{
+		MaxOpenConnections:  25,
+		MaxIdleConnections:  25,
+		IdleTimeout:         5 * time.Minute,
+		ConnectRetries:      3,
+		ConnectRetryBackoff: 500 * time.Millisecond,
+	}, db.PoolConfig())
+}
+
+func TestSetPoolConfigLivePool(t *testing.T) {
+	db, _ := setupMockDatabase(t)
+	db.SetPoolConfig(PoolConfig{MaxOpenConnections: 7, MaxIdleConnections: 3, MaxLifetime: time.Hour})
+
+	assert.Equal(t, 7, db.MaxDatabaseOpenConnections)
+	assert.Equal(t, 3, db.MaxDatabaseIdleConnections)
+	assert.Equal(t, time.Hour, db.DatabaseMaxLifetime)
+	assert.Equal(t, 7, db.dbConnection.Stats().MaxOpenConnections)
+}
+
+func TestConnectRetries(t *testing.T) {
+	var logs bytes.Buffer
+	db := NewDatabase("user@tcp(127.0.0.1:1)/test", slog.New(slog.NewTextHandler(&logs, nil)))
+	db.ConnectRetries = 2
+	db.ConnectRetryBackoff = time.Millisecond
+
+	_, err := db.getConnection(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 2, strings.Count(logs.String(), "Unable to Ping Database"))
+}
+
+func TestRetryDelay(t *testing.T) {
+	config := PoolConfig{ConnectRetryBackoff: 100 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, config.retryDelay(0))
+	assert.Equal(t, 200*time.Millisecond, config.retryDelay(1))
+	assert.Equal(t, 400*time.Millisecond, config.retryDelay(2))
+
+	// the doubling stops at the cap instead of overflowing
+	assert.Equal(t, MaxConnectRetryDelay, config.retryDelay(9))
+	assert.Equal(t, MaxConnectRetryDelay, config.retryDelay(40))
+	assert.Equal(t, MaxConnectRetryDelay, config.retryDelay(1000))
+
+	config.ConnectRetryBackoff = time.Hour
+	assert.Equal(t, MaxConnectRetryDelay, config.retryDelay(0))
+}
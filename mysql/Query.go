@@ -1,81 +1,109 @@
 package mysql
 
 import (
-	"fmt"
-	l "log/slog"
+	"context"
 )
 
 func (db *Database) Query(sql string, parameters ...any) ([]Record, error) {
+	return db.QueryContext(context.Background(), sql, parameters...)
+}
 
-	allRecords := make([]Record, 0)
+// QueryContext runs a query, passing the context's cancellation and deadline down to the driver.
+func (db *Database) QueryContext(ctx context.Context, sql string, parameters ...any) ([]Record, error) {
 
-	DatabaseConnection, err := getConnection()
+	DatabaseConnection, err := db.getConnection(ctx)
 	if err != nil {
-		return allRecords, err
+		return make([]Record, 0), err
 	}
+	return db.query(ctx, DatabaseConnection, sql, parameters...)
+}
 
-	rows, err := DatabaseConnection.Query(sql, parameters...)
+// query runs a query on a pool or transaction and reads every row into a Record
+func (db *Database) query(ctx context.Context, exec executor, sql string, parameters ...any) ([]Record, error) {
 
+	allRecords := make([]Record, 0)
+
+	cursor, err := db.queryCursor(ctx, exec, sql, parameters...)
 	if err != nil {
 		return allRecords, err
 	}
-	defer rows.Close()
+	defer cursor.Close()
 
-	columns, err := rows.Columns()
-	if err != nil {
-		l.Error(fmt.Sprintf("Error while fetching column names, err: %s\n", err.Error()))
+	for cursor.Next() {
+		out, err := cursor.Record()
+		if err != nil {
+			return make([]Record, 0), err
+		}
+		allRecords = append(allRecords, out)
+	}
+	if err := cursor.Err(); err != nil {
+		return make([]Record, 0), err
 	}
-	count := len(columns)
-	values := make([]interface{}, count)
-	valuePtrs := make([]interface{}, count)
 
-	for rows.Next() {
+	return allRecords, nil
+}
 
-		for i := range columns {
-			valuePtrs[i] = &values[i]
-		}
-		if err := rows.Scan(valuePtrs...); err != nil {
-			l.Error(fmt.Sprintf("Error while scanning in query: %s\n", err.Error()))
-		}
+// QueryEach runs a query and calls fn for each row as it is read, without holding the whole result in memory.
+// Iteration stops at the first error fn returns, which is then returned.
+func (db *Database) QueryEach(sql string, fn func(Record) error, parameters ...any) error {
+	return db.QueryEachContext(context.Background(), sql, fn, parameters...)
+}
+
+// QueryEachContext is QueryEach with the query run under ctx
+func (db *Database) QueryEachContext(ctx context.Context, sql string, fn func(Record) error, parameters ...any) error {
+	return queryEach(ctx, db, sql, fn, parameters...)
+}
+
+func queryEach(ctx context.Context, h Handle, sql string, fn func(Record) error, parameters ...any) error {
+	cursor, err := h.QueryCursor(ctx, sql, parameters...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
 
-		out := Record{}
-
-		for i, col := range columns {
-			val := values[i]
-
-			// TODO: Implement All the Types!
-
-			// nolint:gosimple
-			switch val.(type) {
-			case uint, uint8, uint16, uint32, uint64, int, int8, int16, int32, int64:
-				// fmt.Printf("Int: %v\n", val)
-				out[col] = Field{Value: val}
-			case float32, float64:
-				// fmt.Printf("Float64: %v\n", val)
-				out[col] = Field{Value: val}
-			case bool:
-				out[col] = Field{Value: val}
-			case string:
-				out[col] = Field{Value: val}
-
-			case []uint8:
-				b, _ := val.([]byte)
-				// fmt.Printf("String: %s\n", string(b))
-				// l.INFO("Type: %T", val)
-				out[col] = Field{Value: string(b)}
-
-			case interface{}:
-				// l.ERROR("Unknown Type: %T", val)
-				// If the Record is NULL
-				out[col] = Field{Value: val}
-
-			default:
-				// l.ERROR("Unknown Type: %T", val)
-				out[col] = Field{Value: val}
-			}
+	for cursor.Next() {
+		record, err := cursor.Record()
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
 		}
-		allRecords = append(allRecords, out)
 	}
+	return cursor.Err()
+}
 
-	return allRecords, nil
+// newField wraps a value read from the driver, turning byte slices into strings
+func newField(val any) Field {
+
+	// TODO: Implement All the Types!
+
+	// nolint:gosimple
+	switch val.(type) {
+	case uint, uint8, uint16, uint32, uint64, int, int8, int16, int32, int64:
+		// fmt.Printf("Int: %v\n", val)
+		return Field{Value: val}
+	case float32, float64:
+		// fmt.Printf("Float64: %v\n", val)
+		return Field{Value: val}
+	case bool:
+		return Field{Value: val}
+	case string:
+		return Field{Value: val}
+
+	case []uint8:
+		b, _ := val.([]byte)
+		// fmt.Printf("String: %s\n", string(b))
+		// l.INFO("Type: %T", val)
+		return Field{Value: string(b)}
+
+	case interface{}:
+		// l.ERROR("Unknown Type: %T", val)
+		// If the Record is NULL
+		return Field{Value: val}
+
+	default:
+		// l.ERROR("Unknown Type: %T", val)
+		return Field{Value: val}
+	}
 }
@@ -1,136 +1,108 @@
 package mysql
 
 import (
+	"context"
+	"fmt"
 	"reflect"
-
-	l "log/slog"
 )
 
 // You can't do Method Generic types in Go, so we have to use a function.
 
+// QueryStruct runs the query against the package-level DB and maps each row into a T.
 func QueryStruct[T any](sql string, parameters ...any) ([]T, error) {
+	return QueryStructWith[T](DB, sql, parameters...)
+}
+
+// QueryStructWith runs the query against the given database or transaction and maps each row into a T.
+func QueryStructWith[T any](h Handle, sql string, parameters ...any) ([]T, error) {
+	return QueryStructContext[T](context.Background(), h, sql, parameters...)
+}
 
-	// First of all, get all the database records, ising the old Record/Field method.
-	allRecords, err := DB.Query(sql, parameters...)
+// QueryStructContext runs the query against the given handle under ctx and maps each row into a T, scanning the
+// columns straight into the struct fields.
+func QueryStructContext[T any](ctx context.Context, h Handle, sql string, parameters ...any) ([]T, error) {
+
+	results := make([]T, 0)
+	err := QueryStructEachContext[T](ctx, h, sql, func(row T) error {
+		results = append(results, row)
+		return nil
+	}, parameters...)
 	if err != nil {
 		return make([]T, 0), err
 	}
+	return results, nil
+}
 
-	results := make([]T, 0)
+// QueryStructEach runs the query against the package-level DB and calls fn with each row mapped into a T as it is
+// read, without holding the whole result in memory. Iteration stops at the first error fn returns.
+func QueryStructEach[T any](sql string, fn func(T) error, parameters ...any) error {
+	return QueryStructEachWith[T](DB, sql, fn, parameters...)
+}
 
-	for i, record := range allRecords {
-		var newStructRecord T
+// QueryStructEachWith is QueryStructEach against the given database or transaction
+func QueryStructEachWith[T any](h Handle, sql string, fn func(T) error, parameters ...any) error {
+	return QueryStructEachContext[T](context.Background(), h, sql, fn, parameters...)
+}
 
-		for k, v := range record {
-			// Use Reflection to set the value.
-
-			structFieldName, structFieldType := getStructDetails[T](k)
-
-			// l.INFO("index:%d Key:%s Value:%v structFieldName:%v structFieldType:%v", i, k, "", structFieldName, structFieldType)
-
-			switch structFieldType {
-			case "int", "int8", "int16", "int32", "int64":
-				// l.INFO("Setting Int64 field: %s to %v type: %T", structFieldName, v.Value, v.Value)
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).SetInt(v.AsInt64())
-
-			case "*int", "*int8", "*int16", "*int32", "*int64":
-				// l.INFO("Setting Int64 field: %s to %v type: %T", structFieldName, v.Value, v.Value)
-				var valueOf reflect.Value
-				switch structFieldType {
-				case "*int":
-					valueOf = toIntPtr[int](v.AsInt64Ptr())
-				case "*int8":
-					valueOf = toIntPtr[int8](v.AsInt64Ptr())
-				case "*int16":
-					valueOf = toIntPtr[int16](v.AsInt64Ptr())
-				case "*int32":
-					valueOf = toIntPtr[int32](v.AsInt64Ptr())
-				case "*int64":
-					valueOf = reflect.ValueOf(v.AsInt64Ptr())
-				}
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(valueOf)
-
-			case "uint", "uint8", "uint16", "uint32", "uint64":
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).SetUint(v.AsUInt64())
-
-			case "*uint", "*uint8", "*uint16", "*uint32", "*uint64":
-				var valueOf reflect.Value
-				switch structFieldType {
-				case "*uint":
-					valueOf = toUIntPtr[uint](v.AsUInt64Ptr())
-				case "*uint8":
-					valueOf = toUIntPtr[uint8](v.AsUInt64Ptr())
-				case "*uint16":
-					valueOf = toUIntPtr[uint16](v.AsUInt64Ptr())
-				case "*uint32":
-					valueOf = toUIntPtr[uint32](v.AsUInt64Ptr())
-				case "*uint64":
-					valueOf = reflect.ValueOf(v.AsUInt64Ptr())
-				}
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(valueOf)
-
-			case "bool":
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).SetBool(v.AsBool())
-
-			case "*bool":
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(reflect.ValueOf(v.AsBoolPtr()))
-
-			case "float32", "float64":
-				// l.INFO("Setting flaot64 field: %s to %v", structFieldName, v.Value)
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).SetFloat(v.AsFloat())
-
-			case "*float32", "*float64":
-				var valueOf reflect.Value
-				switch structFieldType {
-				case "*float32":
-					valueOf = toFloatPtr[float32](v.AsFloatPtr())
-				case "*float64":
-					valueOf = reflect.ValueOf(v.AsFloatPtr())
-				}
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(valueOf)
-
-			case "string":
-				// l.INFO("Setting String field: %s to %v", structFieldName, v.Value)
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).SetString(v.AsString())
-			case "*string":
-				// l.INFO("Setting String field: %s to %v", structFieldName, v.Value)
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(reflect.ValueOf(v.AsStringPtr()))
-
-			case "Time":
-				// l.INFO("Setting Time field: %s to %v", structFieldName, v.Value)
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(reflect.ValueOf(v.AsDate("")))
-
-			case "*Time":
-				// l.INFO("Setting String field: %s to %v", structFieldName, v.Value)
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(reflect.ValueOf(v.AsDatePtr("")))
-
-				// Add Blob Support.
-			case "[]uint8":
-				reflect.ValueOf(&newStructRecord).Elem().FieldByName(structFieldName).Set(reflect.ValueOf(v.AsByte()))
-				// l.INFO("Setting Blob field: %s to %v", structFieldName, v.Value)
-
-			default:
-				l.With("col", k).With("index", i).With("structFieldName", structFieldName).With("structFieldType", structFieldType).Error("Database column was not found")
-			}
-		}
+// QueryStructEachContext is QueryStructEach against the given handle under ctx
+func QueryStructEachContext[T any](ctx context.Context, h Handle, sql string, fn func(T) error, parameters ...any) error {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct type, got %s", structType)
+	}
 
-		results = append(results, newStructRecord)
+	cursor, err := h.QueryCursor(ctx, sql, parameters...)
+	if err != nil {
+		return err
 	}
-	return results, nil
+	defer cursor.Close()
+
+	for cursor.Next() {
+		var newStructRecord T
+		if err := cursor.Scan(&newStructRecord); err != nil {
+			return err
+		}
+		if err := fn(newStructRecord); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
 }
 
 // You can't do Method Generic types in Go, so we have to use a function.
 
+// QuerySingleStruct returns the first row of the query against the package-level DB, or the zero T when there are none.
 func QuerySingleStruct[T any](sql string, parameters ...any) (T, error) {
+	return QuerySingleStructWith[T](DB, sql, parameters...)
+}
+
+// QuerySingleStructWith returns the first row of the query against the given database or transaction, or the zero T when there are none.
+func QuerySingleStructWith[T any](h Handle, sql string, parameters ...any) (T, error) {
+	return QuerySingleStructContext[T](context.Background(), h, sql, parameters...)
+}
+
+// QuerySingleStructContext returns the first row of the query against the given handle under ctx, or the zero T
+// when there are none. The rows after the first are not read.
+func QuerySingleStructContext[T any](ctx context.Context, h Handle, sql string, parameters ...any) (T, error) {
 
 	var SingleResult T
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		return SingleResult, fmt.Errorf("expected a struct type, got %s", structType)
+	}
 
-	results, err := QueryStruct[T](sql, parameters...)
+	cursor, err := h.QueryCursor(ctx, sql, parameters...)
 	if err != nil {
 		return SingleResult, err
 	}
-	if len(results) == 0 {
-		return SingleResult, nil
+	defer cursor.Close()
+
+	if !cursor.Next() {
+		return SingleResult, cursor.Err()
+	}
+	var row T
+	if err := cursor.Scan(&row); err != nil {
+		return SingleResult, err
 	}
-	return results[0], nil
+	return row, nil
 }
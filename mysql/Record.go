@@ -1,78 +1,88 @@
 package mysql
 
 import (
-    "fmt"
-    "strings"
-    "time"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
 )
 
 type Record map[string]Field
 
 func (db *Database) RecordUpdate(RecordToUpdate Record, UpdateTable string, UpdateColumn string, UpdateColumnValue string) (int64, error) {
-    
-    // Build an SQL Statement Based on the Record.
-    buildsql := "UPDATE " + UpdateTable + " SET "
-    
-    for key, F := range RecordToUpdate {
-        buildsql = buildsql + key + " = "
-        
-        switch v := F.Value.(type) {
-        case int, int32, int64:
-            buildsql = buildsql + fmt.Sprintf("%v", F.Value) + ","
-        case float64:
-            buildsql = buildsql + fmt.Sprintf("%v", F.Value) + ","
-        case string:
-            buildsql = buildsql + hexRepresentation(F.Value.(string)) + ","
-        case time.Time:
-            buildsql = buildsql + fmt.Sprintf("'%s'", F.Value.(time.Time).Format("2006-01-02 15:04:05")) + ","
-        default:
-            DB.Logger.Error(fmt.Sprintf("%v is unknown", v))
-            buildsql = buildsql + "'" + F.Value.(string) + "',"
-        }
-        
-    }
-    buildsql = strings.TrimSuffix(buildsql, ",")
-    buildsql = buildsql + " WHERE " + UpdateColumn + " = " + UpdateColumnValue
-    
-    _, RowsAffected, err := DB.Execute(buildsql)
-    if err != nil {
-        return RowsAffected, err
-    }
-    return RowsAffected, nil
+	return db.RecordUpdateContext(context.Background(), RecordToUpdate, UpdateTable, UpdateColumn, UpdateColumnValue)
+}
+
+// RecordUpdateContext is RecordUpdate with the statement run under ctx
+func (db *Database) RecordUpdateContext(ctx context.Context, RecordToUpdate Record, UpdateTable string, UpdateColumn string, UpdateColumnValue string) (int64, error) {
+	return recordUpdate(ctx, db, RecordToUpdate, UpdateTable, UpdateColumn, UpdateColumnValue)
+}
+
+func recordUpdate(ctx context.Context, h Handle, RecordToUpdate Record, UpdateTable string, UpdateColumn string, UpdateColumnValue string) (int64, error) {
+
+	// Build an SQL Statement Based on the Record, with the values bound as parameters.
+	columns := RecordToUpdate.columns()
+	set := make([]string, 0, len(columns))
+	args := make([]any, 0, len(columns)+1)
+
+	for _, key := range columns {
+		value, err := writeValue(RecordToUpdate[key].Value)
+		if err != nil {
+			return 0, fmt.Errorf("column %s: %w", key, err)
+		}
+		set = append(set, key+" = ?")
+		args = append(args, value)
+	}
+	args = append(args, UpdateColumnValue)
+
+	buildsql := "UPDATE " + UpdateTable + " SET " + strings.Join(set, ",") + " WHERE " + UpdateColumn + " = ?"
+
+	_, RowsAffected, err := h.ExecuteContext(ctx, buildsql, args...)
+	if err != nil {
+		return RowsAffected, err
+	}
+	return RowsAffected, nil
 }
 
 func (db *Database) RecordInsert(RecordToInsert Record, InsertTable string) (int64, error) {
-    
-    // Build an SQL Statement Based on the Record.
-    buildsql := "INSERT INTO " + InsertTable + "("
-    endsql := ""
-    
-    for key, F := range RecordToInsert {
-        buildsql = buildsql + key + ","
-        
-        switch v := F.Value.(type) {
-        case int, int32, int64:
-            endsql = endsql + fmt.Sprintf("%v", F.AsInt()) + ","
-        case string:
-            endsql = endsql + hexRepresentation(F.Value.(string)) + ","
-        case float64:
-            endsql = endsql + fmt.Sprintf("%v", F.Value) + ","
-        case time.Time:
-            endsql = endsql + fmt.Sprintf("'%s'", F.Value.(time.Time).Format("2006-01-02 15:04:05")) + ","
-        default:
-            DB.Logger.Error(fmt.Sprintf("%v is unknown", v))
-            endsql = endsql + "'" + F.Value.(string) + "',"
-        }
-        
-    }
-    buildsql = strings.TrimSuffix(buildsql, ",")
-    endsql = strings.TrimSuffix(endsql, ",")
-    buildsql = buildsql + ") VALUES (" + endsql + ");"
-    
-    id, _, err := DB.Execute(buildsql)
-    if err != nil {
-        return 0, err
-    }
-    
-    return id, nil
+	return db.RecordInsertContext(context.Background(), RecordToInsert, InsertTable)
+}
+
+// RecordInsertContext is RecordInsert with the statement run under ctx
+func (db *Database) RecordInsertContext(ctx context.Context, RecordToInsert Record, InsertTable string) (int64, error) {
+	return recordInsert(ctx, db, RecordToInsert, InsertTable)
+}
+
+func recordInsert(ctx context.Context, h Handle, RecordToInsert Record, InsertTable string) (int64, error) {
+
+	// Build an SQL Statement Based on the Record, with the values bound as parameters.
+	columns := RecordToInsert.columns()
+	args := make([]any, 0, len(columns))
+
+	for _, key := range columns {
+		value, err := writeValue(RecordToInsert[key].Value)
+		if err != nil {
+			return 0, fmt.Errorf("column %s: %w", key, err)
+		}
+		args = append(args, value)
+	}
+
+	buildsql := "INSERT INTO " + InsertTable + "(" + strings.Join(columns, ",") + ") VALUES " + placeholdersSql(len(columns)) + ";"
+
+	id, _, err := h.ExecuteContext(ctx, buildsql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// columns returns the column names of the Record in sorted order, so the generated SQL is stable
+func (R Record) columns() []string {
+	columns := make([]string, 0, len(R))
+	for key := range R {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+	return columns
 }
@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordInsert(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	dtadded := time.Date(2024, time.December, 7, 15, 29, 25, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO Users(dtadded,name,status) VALUES (?,?,?);").
+		WithArgs(dtadded, "O'Brien", 1).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	id, err := db.RecordInsert(Record{
+		"name":    Field{Value: "O'Brien"},
+		"status":  Field{Value: 1},
+		"dtadded": Field{Value: dtadded},
+	}, "Users")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordUpdate(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("UPDATE Users SET name = ?,status = ? WHERE id = ?").
+		WithArgs("O'Brien", 2, "1 OR 1=1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rowsAffected, err := db.RecordUpdate(Record{
+		"name":   Field{Value: "O'Brien"},
+		"status": Field{Value: 2},
+	}, "Users", "id", "1 OR 1=1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,28 +1,146 @@
 package mysql
 
 import (
+	"context"
 	"errors"
 	"reflect"
 )
 
 // Save takes in a structure and if the primary key value is set to a non-zero value, then it will update the object
-// else it will insert the object into the table (taking in a primary key to reduce reflection overhead)
-func (db *Database) Save(dbStructure any, primaryKeyValue any) (lastInsertedID, rowsAffected int64, err error) {
-	pkvValue := reflect.ValueOf(primaryKeyValue) //pkv => Primary Key Value
-	if !pkvValue.IsValid() {
-		return 0, 0, errors.New("invalid primary key value")
-	}
-	var sql string
-	if pkvValue.IsZero() {
-		sql, err = DB.Insert(dbStructure)
-		if err != nil {
-			return 0, 0, err
+// else it will insert the object into the table. The primary key values are read from the fields tagged primarykey=yes
+// unless passed, and a composite key is only updated when every part is non-zero. When the structure is passed as a
+// pointer, an insert sets the generated ID on it. An update of a structure with a version=yes column returns
+// ErrStaleObject when the row was changed since it was read.
+func (db *Database) Save(dbStructure any, primaryKeyValue ...any) (lastInsertedID, rowsAffected int64, err error) {
+	return db.SaveContext(context.Background(), dbStructure, primaryKeyValue...)
+}
+
+// SaveContext is Save with the statement run under ctx
+func (db *Database) SaveContext(ctx context.Context, dbStructure any, primaryKeyValue ...any) (lastInsertedID, rowsAffected int64, err error) {
+	return save(ctx, db, dbStructure, primaryKeyValue...)
+}
+
+// InsertExec inserts the structure and, when it is passed as a pointer, sets the generated ID on its primary key
+func (db *Database) InsertExec(dbStructure any) (lastInsertedID, rowsAffected int64, err error) {
+	return db.InsertExecContext(context.Background(), dbStructure)
+}
+
+// InsertExecContext is InsertExec with the statement run under ctx
+func (db *Database) InsertExecContext(ctx context.Context, dbStructure any) (lastInsertedID, rowsAffected int64, err error) {
+	return insertExec(ctx, db, dbStructure)
+}
+
+// save builds the insert or update for the structure and runs it on the handle
+func save(ctx context.Context, h Handle, dbStructure any, primaryKeyValue ...any) (lastInsertedID, rowsAffected int64, err error) {
+	if len(primaryKeyValue) > 0 {
+		for _, value := range primaryKeyValue {
+			if !reflect.ValueOf(value).IsValid() {
+				return 0, 0, errors.New("invalid primary key value")
+			}
 		}
 	} else {
-		sql, err = DB.Update(dbStructure)
+		_, primaryKeys, _, err := h.database().structColumns(dbStructure)
 		if err != nil {
 			return 0, 0, err
 		}
+		if len(primaryKeys) == 0 {
+			return 0, 0, errors.New("no primary key set, unable to set a where clause")
+		}
+		for _, c := range primaryKeys {
+			primaryKeyValue = append(primaryKeyValue, c.value)
+		}
+	}
+
+	for _, value := range primaryKeyValue {
+		// a nil pointer key stays invalid, and is inserted like a zero one
+		pkvValue := reflect.ValueOf(value) //pkv => Primary Key Value
+		if !pkvValue.IsValid() || pkvValue.IsZero() {
+			return insertExec(ctx, h, dbStructure)
+		}
+	}
+	table, primaryKeys, columns, err := h.database().updateColumns(dbStructure)
+	if err != nil {
+		return 0, 0, err
+	}
+	sql, args, err := updateSql(table, primaryKeys, columns, columns)
+	if err != nil {
+		return 0, 0, err
+	}
+	lastInsertedID, rowsAffected, err = h.ExecuteContext(ctx, sql, args...)
+	if err != nil {
+		return lastInsertedID, rowsAffected, err
+	}
+	if err := h.database().checkVersion(dbStructure, rowsAffected); err != nil {
+		return lastInsertedID, rowsAffected, err
+	}
+	setStamped(dbStructure, columns)
+	return lastInsertedID, rowsAffected, nil
+}
+
+// insertExec runs the insert for the structure on the handle and sets the generated ID, version and timestamps on it
+func insertExec(ctx context.Context, h Handle, dbStructure any) (lastInsertedID, rowsAffected int64, err error) {
+	table, columns, err := h.database().insertColumns(dbStructure)
+	if err != nil {
+		return 0, 0, err
+	}
+	sql, args := insertSql(table, columns)
+	lastInsertedID, rowsAffected, err = h.ExecuteContext(ctx, sql, args...)
+	if err != nil {
+		return lastInsertedID, rowsAffected, err
+	}
+	h.database().setPrimaryKey(dbStructure, lastInsertedID)
+	h.database().insertedVersion(dbStructure)
+	setStamped(dbStructure, columns)
+	return lastInsertedID, rowsAffected, nil
+}
+
+// setPrimaryKey sets id on the integer primary key of the structure dbStructure points to, which for a composite key
+// is the part tagged autoincrement=yes, or else the only part still zero. Structures passed by value, keys of other
+// types and IDs that don't fit are left alone.
+func (db *Database) setPrimaryKey(dbStructure any, id int64) {
+	v := reflect.ValueOf(dbStructure)
+	if id <= 0 || v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	primaryKeys := db.structInfo(v.Elem().Type()).primaryKeys
+	var primaryKey *fieldInfo
+	for _, fi := range primaryKeys {
+		if fi.autoIncrement {
+			primaryKey = fi
+			break
+		}
+		if len(primaryKeys) > 1 && !v.Elem().FieldByIndex(fi.index).IsZero() {
+			continue
+		}
+		if primaryKey != nil {
+			return
+		}
+		primaryKey = fi
+	}
+	if primaryKey == nil {
+		return
+	}
+
+	field := v.Elem().FieldByIndex(primaryKey.index)
+	target := field
+	if field.Kind() == reflect.Pointer {
+		target = reflect.New(field.Type().Elem()).Elem()
+	}
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if target.OverflowInt(id) {
+			return
+		}
+		target.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if target.OverflowUint(uint64(id)) {
+			return
+		}
+		target.SetUint(uint64(id))
+	default:
+		return
+	}
+	if field.Kind() == reflect.Pointer {
+		field.Set(target.Addr())
 	}
-	return DB.Execute(sql)
 }
@@ -167,7 +167,8 @@ func TestSaveIntegrationUintType(t *testing.T) {
 	testIntegrationSaveTestHelper[uint32](t, testUint32Cases)
 	testUint64Cases := []IntegrationTestingSaveTestCase[uint64]{
 		{"Uint64 Zero", uint64(0), `BIGINT UNSIGNED`},
-		{"Uint64 Non-Zero", uint64(18446744073709551615), `BIGINT UNSIGNED`},
+		// database/sql rejects uint64 arguments with the high bit set, and sqlite stores larger integers as REAL
+		{"Uint64 Non-Zero", uint64(9223372036854775807), `BIGINT UNSIGNED`},
 	}
 	testIntegrationSaveTestHelper[uint64](t, testUint64Cases)
 }
@@ -19,12 +19,22 @@ type SavePersonTime struct {
 	Status  int       `db:"column=status"`
 }
 
+// uint64Converter accepts uint64 values with the high bit set, like the MySQL driver does
+type uint64Converter struct{}
+
+func (uint64Converter) ConvertValue(v any) (driver.Value, error) {
+	if u, ok := v.(uint64); ok {
+		return u, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
 // setupSaveTestMock sets up the mocks using sqlmock library
 func setupSaveTestMock(t *testing.T, sql string, params ...driver.Value) (*sqlmock.Sqlmock, *sqlmock.ExpectedExec) {
 	New("test/test", slog.Default())
 	var err error
 	var mock sqlmock.Sqlmock
-	DB.dbConnection, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	DB.dbConnection, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual), sqlmock.ValueConverterOption(uint64Converter{}))
 	DB.connected = true
 	assert.NoError(t, err)
 	expectedExec := mock.ExpectExec(sql)
@@ -36,7 +46,7 @@ func setupSaveTestMock(t *testing.T, sql string, params ...driver.Value) (*sqlmo
 
 // TestSaveNormalInsert tests normal insert
 func TestSaveNormalInsert(t *testing.T) {
-	mock, expectedExec := setupSaveTestMock(t, `INSERT INTO Users(name,status) VALUES (X'54657374',31);`)
+	mock, expectedExec := setupSaveTestMock(t, `INSERT INTO Users(name,status) VALUES (?,?);`, "Test", 31)
 	expectedExec.WillReturnResult(sqlmock.NewResult(1, 1))
 	entry := SavePersonTime{0, "Test", time.Now(), 31}
 	lastInsertedID, rowsAffected, err := DB.Save(entry, entry.Id)
@@ -48,7 +58,7 @@ func TestSaveNormalInsert(t *testing.T) {
 
 // TestSaveNormalUpdate tests normal update
 func TestSaveNormalUpdate(t *testing.T) {
-	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=X'54657374',status=31 WHERE id=1;`)
+	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=?,status=? WHERE id=?;`, "Test", 31, 1)
 	expectedExec.WillReturnResult(sqlmock.NewResult(0, 1))
 	entry := SavePersonTime{1, "Test", time.Now(), 31}
 	lastInsertedID, rowsAffected, err := DB.Save(entry, entry.Id)
@@ -60,7 +70,7 @@ func TestSaveNormalUpdate(t *testing.T) {
 
 // TestSaveNoColumn tests no column field struct
 func TestSaveNoColumn(t *testing.T) {
-	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=X'54657374',status=31 WHERE id=1;`)
+	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=?,status=? WHERE id=?;`)
 	expectedExec.WillReturnResult(sqlmock.NewResult(1, 1))
 	type NoColumn struct {
 		Id      int       `db:"column=id primarykey=yes table=Users"`
@@ -80,7 +90,7 @@ func TestSaveNoColumn(t *testing.T) {
 
 // TestSaveNoPKVUpdate tests no pkv in struct with update
 func TestSaveNoPKVUpdate(t *testing.T) {
-	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=X'54657374',status=31 WHERE id=1;`)
+	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=?,status=? WHERE id=?;`)
 	expectedExec.WillReturnResult(sqlmock.NewResult(1, 1))
 	entry := struct {
 		Id      int       `db:"column=id table=Users"`
@@ -97,7 +107,7 @@ func TestSaveNoPKVUpdate(t *testing.T) {
 
 // TestSaveEmptyStruct tests empty struct
 func TestSaveEmptyStruct(t *testing.T) {
-	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=X'54657374',status=31 WHERE id=1;`)
+	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=?,status=? WHERE id=?;`)
 	expectedExec.WillReturnResult(sqlmock.NewResult(1, 1))
 	entry := struct{}{}
 	_, _, err := DB.Save(entry, 0)
@@ -125,52 +135,56 @@ func TestSavePrimaryKeyTypes(t *testing.T) {
 		expectedIsInsert bool
 	}{
 		// Unsigned Integers
-		{"Uint Zero", uint(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Uint Non-Zero", uint(42), `UPDATE Users SET name=X'54657374',status=31 WHERE id=42;`, false},
-		{"Uint8 Zero", uint8(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Uint8 Non-Zero", uint8(255), `UPDATE Users SET name=X'54657374',status=31 WHERE id=255;`, false},
-		{"Uint16 Zero", uint16(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Uint16 Non-Zero", uint16(65535), `UPDATE Users SET name=X'54657374',status=31 WHERE id=65535;`, false},
-		{"Uint32 Zero", uint32(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Uint32 Non-Zero", uint32(4294967295), `UPDATE Users SET name=X'54657374',status=31 WHERE id=4294967295;`, false},
-		{"Uint64 Zero", uint64(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Uint64 Non-Zero", uint64(18446744073709551615), `UPDATE Users SET name=X'54657374',status=31 WHERE id=18446744073709551615;`, false},
+		{"Uint Zero", uint(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Uint Non-Zero", uint(42), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Uint8 Zero", uint8(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Uint8 Non-Zero", uint8(255), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Uint16 Zero", uint16(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Uint16 Non-Zero", uint16(65535), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Uint32 Zero", uint32(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Uint32 Non-Zero", uint32(4294967295), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Uint64 Zero", uint64(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Uint64 Non-Zero", uint64(18446744073709551615), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
 
 		// Signed Integers
-		{"Int Zero", 0, `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Int Positive", 42, `UPDATE Users SET name=X'54657374',status=31 WHERE id=42;`, false},
-		{"Int Negative", -42, `UPDATE Users SET name=X'54657374',status=31 WHERE id=-42;`, false},
-		{"Int8 Zero", int8(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Int8 Positive", int8(127), `UPDATE Users SET name=X'54657374',status=31 WHERE id=127;`, false},
-		{"Int8 Negative", int8(-128), `UPDATE Users SET name=X'54657374',status=31 WHERE id=-128;`, false},
-		{"Int16 Zero", int16(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Int16 Positive", int16(32767), `UPDATE Users SET name=X'54657374',status=31 WHERE id=32767;`, false},
-		{"Int16 Negative", int16(-32768), `UPDATE Users SET name=X'54657374',status=31 WHERE id=-32768;`, false},
-		{"Int32 Zero", int32(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Int32 Positive", int32(2147483647), `UPDATE Users SET name=X'54657374',status=31 WHERE id=2147483647;`, false},
-		{"Int32 Negative", int32(-2147483648), `UPDATE Users SET name=X'54657374',status=31 WHERE id=-2147483648;`, false},
-		{"Int64 Zero", int64(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Int64 Positive", int64(9223372036854775807), `UPDATE Users SET name=X'54657374',status=31 WHERE id=9223372036854775807;`, false},
-		{"Int64 Negative", int64(-9223372036854775808), `UPDATE Users SET name=X'54657374',status=31 WHERE id=-9223372036854775808;`, false},
+		{"Int Zero", 0, `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Int Positive", 42, `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int Negative", -42, `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int8 Zero", int8(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Int8 Positive", int8(127), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int8 Negative", int8(-128), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int16 Zero", int16(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Int16 Positive", int16(32767), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int16 Negative", int16(-32768), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int32 Zero", int32(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Int32 Positive", int32(2147483647), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int32 Negative", int32(-2147483648), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int64 Zero", int64(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Int64 Positive", int64(9223372036854775807), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Int64 Negative", int64(-9223372036854775808), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
 
 		// Floating Point
-		{"Float32 Zero", float32(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Float32 Positive", float32(3.14), `UPDATE Users SET name=X'54657374',status=31 WHERE id=3.14;`, false},
-		{"Float32 Negative", float32(-3.14), `UPDATE Users SET name=X'54657374',status=31 WHERE id=-3.14;`, false},
-		{"Float64 Zero", float64(0), `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"Float64 Positive", float64(3.14159), `UPDATE Users SET name=X'54657374',status=31 WHERE id=3.14159;`, false},
-		{"Float64 Negative", float64(-3.14159), `UPDATE Users SET name=X'54657374',status=31 WHERE id=-3.14159;`, false},
+		{"Float32 Zero", float32(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Float32 Positive", float32(3.14), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Float32 Negative", float32(-3.14), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Float64 Zero", float64(0), `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"Float64 Positive", float64(3.14159), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
+		{"Float64 Negative", float64(-3.14159), `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
 
 		// String
-		{"String Empty", "", `INSERT INTO Users(name,status) VALUES (X'54657374',31);`, true},
-		{"String Non-Empty", "42", `UPDATE Users SET name=X'54657374',status=31 WHERE id=42;`, false},
+		{"String Empty", "", `INSERT INTO Users(name,status) VALUES (?,?);`, true},
+		{"String Non-Empty", "42", `UPDATE Users SET name=?,status=? WHERE id=?;`, false},
 	}
 
 	for _, tc := range testCases {
 
 		t.Run(tc.name, func(t *testing.T) {
 			// setup mock
-			mock, expectedExec := setupSaveTestMock(t, tc.expectedQuery)
+			params := []driver.Value{"Test", 31}
+			if !tc.expectedIsInsert {
+				params = append(params, tc.primaryKeyValue)
+			}
+			mock, expectedExec := setupSaveTestMock(t, tc.expectedQuery, params...)
 
 			// prepare test entry
 			entry := GenericEntity{
@@ -231,7 +245,7 @@ func TestSavePrimaryKeyTypes(t *testing.T) {
 
 // TestSaveInsertError tests with db specific insert error
 func TestSaveInsertError(t *testing.T) {
-	mock, expectedExec := setupSaveTestMock(t, `INSERT INTO Users(name,status) VALUES (X'54657374',31);`)
+	mock, expectedExec := setupSaveTestMock(t, `INSERT INTO Users(name,status) VALUES (?,?);`)
 	expectedExec.WillReturnError(errors.New("dummy error"))
 	entry := SavePersonTime{0, "Test", time.Now(), 31}
 	_, _, err := DB.Save(entry, entry.Id)
@@ -241,10 +255,77 @@ func TestSaveInsertError(t *testing.T) {
 
 // TestSaveUpdateError tests with db specific update error
 func TestSaveUpdateError(t *testing.T) {
-	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=X'54657374',status=31 WHERE id=1;`)
+	mock, expectedExec := setupSaveTestMock(t, `UPDATE Users SET name=?,status=? WHERE id=?;`)
 	expectedExec.WillReturnError(errors.New("dummy error"))
 	entry := SavePersonTime{1, "Test", time.Now(), 31}
 	_, _, err := DB.Save(entry, entry.Id)
 	assert.EqualError(t, err, "dummy error")
 	assert.NoError(t, (*mock).ExpectationsWereMet())
 }
+
+// TestSavePointerSetsID tests the primary key is inferred from the tag and the generated ID is written back
+func TestSavePointerSetsID(t *testing.T) {
+	mock, expectedExec := setupSaveTestMock(t, `INSERT INTO Users(name,status) VALUES (?,?);`, "Test", 31)
+	expectedExec.WillReturnResult(sqlmock.NewResult(42, 1))
+	(*mock).ExpectExec(`UPDATE Users SET name=?,status=? WHERE id=?;`).
+		WithArgs("Test", 32, 42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	entry := SavePersonTime{0, "Test", time.Now(), 31}
+	lastInsertedID, _, err := DB.Save(&entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), lastInsertedID)
+	assert.Equal(t, 42, entry.Id)
+
+	entry.Status = 32
+	_, rowsAffected, err := DB.Save(&entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	assert.NoError(t, (*mock).ExpectationsWereMet())
+
+	_, _, err = DB.Save(struct {
+		Id   int    `db:"column=id table=Users"`
+		Name string `db:"column=name"`
+	}{1, "Test"})
+	assert.EqualError(t, err, "no primary key set, unable to set a where clause")
+}
+
+func TestInsertExec(t *testing.T) {
+	mock, expectedExec := setupSaveTestMock(t, `INSERT INTO Users(name,status) VALUES (?,?);`, "Test", 31)
+	expectedExec.WillReturnResult(sqlmock.NewResult(7, 1))
+
+	type pointerKey struct {
+		Id     *uint32 `db:"column=id primarykey=yes table=Users"`
+		Name   string  `db:"column=name"`
+		Status int     `db:"column=status"`
+	}
+	entry := pointerKey{nil, "Test", 31}
+	lastInsertedID, rowsAffected, err := DB.InsertExec(&entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), lastInsertedID)
+	assert.Equal(t, int64(1), rowsAffected)
+	if assert.NotNil(t, entry.Id) {
+		assert.Equal(t, uint32(7), *entry.Id)
+	}
+	assert.NoError(t, (*mock).ExpectationsWereMet())
+}
+
+// TestSaveCompositeKey tests a row is only updated when every key part is set, and the generated ID fills the missing one
+func TestSaveCompositeKey(t *testing.T) {
+	mock, expectedExec := setupSaveTestMock(t, `INSERT INTO Users(tenant_id,name) VALUES (?,?);`, 7, "Test")
+	expectedExec.WillReturnResult(sqlmock.NewResult(3, 1))
+	(*mock).ExpectExec(`UPDATE Users SET name=? WHERE tenant_id=? AND id=?;`).
+		WithArgs("Other", 7, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	entry := tenantPerson{TenantId: 7, Name: "Test"}
+	_, _, err := DB.Save(&entry)
+	assert.NoError(t, err)
+	assert.Equal(t, tenantPerson{7, 3, "Test"}, entry)
+
+	entry.Name = "Other"
+	_, rowsAffected, err := DB.Save(&entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	assert.NoError(t, (*mock).ExpectationsWereMet())
+}
@@ -0,0 +1,235 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// scanPlan maps the columns of a result to typed destinations inside a reusable struct value. It is resolved once per
+// query, after which each row is read with a single rows.Scan and no intermediate Record.
+type scanPlan struct {
+	typ     reflect.Type
+	columns []string
+	targets []string
+	row     reflect.Value
+	dests   []any
+	finish  []columnFinish
+}
+
+// columnFinish completes the conversion of a column that could not be scanned into its field directly
+type columnFinish struct {
+	column int
+	fn     func() error
+}
+
+// newScanPlan resolves the destination of each column for the struct type, mapped as the database names it. Columns
+// without a matching field are read and discarded.
+func newScanPlan(db *Database, t reflect.Type, columns []string) (*scanPlan, error) {
+	info := db.structInfo(t)
+	if info.unsupported != nil {
+		return nil, info.unsupported
+	}
+	p := &scanPlan{
+		typ:     t,
+		columns: columns,
+		targets: make([]string, len(columns)),
+		row:     reflect.New(t).Elem(),
+		dests:   make([]any, len(columns)),
+	}
+
+	for i, column := range columns {
+		fi, ok := info.columns[column]
+		if !ok {
+			p.dests[i] = new(any)
+			continue
+		}
+
+		field := p.row.FieldByIndex(fi.index)
+		p.targets[i] = fi.typ.String()
+		if c := lookupConverter(fi.typ); c != nil && c.read != nil {
+			p.dests[i] = p.converterDestination(i, field, c)
+			continue
+		}
+		switch {
+		case fi.scanner:
+			// database/sql calls Scan, allocating the value first for pointer fields
+			p.dests[i] = field.Addr().Interface()
+
+		case fi.typ == timeType || fi.typ == reflect.PointerTo(timeType):
+			// The driver returns DATETIME as text unless parseTime is set, which database/sql can't convert.
+			p.dests[i] = p.timeDestination(i, field)
+
+		case (fi.kind == reflect.Pointer || fi.kind == reflect.Slice) && isScannableKind(fi.typ):
+			// database/sql sets pointers and byte slices to nil for NULL
+			p.dests[i] = field.Addr().Interface()
+
+		case isScannableKind(fi.typ):
+			// Scan through a pointer so NULL leaves the field at its zero value instead of failing the row
+			ptr := reflect.New(reflect.PointerTo(fi.typ))
+			p.dests[i] = ptr.Interface()
+			p.finish = append(p.finish, columnFinish{i, func() error {
+				if !ptr.Elem().IsNil() {
+					field.Set(ptr.Elem().Elem())
+				}
+				return nil
+			}})
+
+		default:
+			db.logger().With("col", column).With("structFieldName", fi.name).With("structFieldType", fi.typeName).Debug("Unsupported field type")
+			p.dests[i] = p.unsupportedDestination(i, fi.typ)
+		}
+	}
+	return p, nil
+}
+
+// timeDestination reads a time column as-is and converts it into the time.Time or *time.Time field afterwards
+func (p *scanPlan) timeDestination(column int, field reflect.Value) any {
+	var src any
+	p.finish = append(p.finish, columnFinish{column, func() error {
+		if src == nil {
+			return nil
+		}
+		t, err := asTime(src)
+		if err != nil {
+			return newConversionError(src, field.Type().String(), err)
+		}
+		if field.Kind() == reflect.Pointer {
+			field.Set(reflect.ValueOf(&t))
+		} else {
+			field.Set(reflect.ValueOf(t))
+		}
+		return nil
+	}})
+	return &src
+}
+
+// converterDestination reads a column as-is and converts it into the field with its registered converter afterwards
+func (p *scanPlan) converterDestination(column int, field reflect.Value, c *converter) any {
+	var src any
+	p.finish = append(p.finish, columnFinish{column, func() error {
+		v, err := readValue(c, field.Type(), src)
+		if err != nil {
+			return newConversionError(src, field.Type().String(), err)
+		}
+		field.Set(v)
+		return nil
+	}})
+	return &src
+}
+
+// unsupportedDestination reads a column whose field has a type there is no conversion for. Only NULL is accepted.
+func (p *scanPlan) unsupportedDestination(column int, t reflect.Type) any {
+	var src any
+	p.finish = append(p.finish, columnFinish{column, func() error {
+		if src == nil {
+			return nil
+		}
+		return newConversionError(src, t.String(), ErrUnsupportedType)
+	}})
+	return &src
+}
+
+// scan reads the current row into dest, which must be a settable value of the plan's struct type. Conversion
+// failures are returned as a *ConversionError naming the column and rowIndex.
+func (p *scanPlan) scan(rows *sql.Rows, dest reflect.Value, rowIndex int) error {
+	p.row.SetZero()
+	if err := rows.Scan(p.dests...); err != nil {
+		return p.scanError(rows, rowIndex, err)
+	}
+	for _, finish := range p.finish {
+		if err := finish.fn(); err != nil {
+			var conversionError *ConversionError
+			if errors.As(err, &conversionError) {
+				conversionError.Column = p.columns[finish.column]
+				conversionError.Row = rowIndex
+			}
+			return err
+		}
+	}
+	dest.Set(p.row)
+	return nil
+}
+
+// scanError works out which column made rows.Scan fail by scanning the row again one destination at a time, and
+// describes it as a *ConversionError. Errors that aren't down to a single column are returned unchanged.
+func (p *scanPlan) scanError(rows *sql.Rows, rowIndex int, err error) error {
+	raw := make([]any, len(p.dests))
+	probe := make([]any, len(p.dests))
+	for i := range raw {
+		probe[i] = &raw[i]
+	}
+	if rows.Scan(probe...) != nil {
+		return err
+	}
+
+	for i, dest := range p.dests {
+		for j := range probe {
+			probe[j] = new(any)
+		}
+		probe[i] = dest
+		if columnErr := rows.Scan(probe...); columnErr != nil {
+			if inner := errors.Unwrap(columnErr); inner != nil {
+				columnErr = inner
+			}
+			return &ConversionError{
+				Column:     p.columns[i],
+				Row:        rowIndex,
+				SourceType: fmt.Sprintf("%T", raw[i]),
+				TargetType: p.targets[i],
+				Err:        columnErr,
+			}
+		}
+	}
+	return err
+}
+
+// isScannableKind reports whether database/sql can scan straight into a value of type t: the basic kinds, byte
+// slices and (nil-able) pointers to those
+func isScannableKind(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// timeLayouts are the text formats MySQL returns DATETIME, TIMESTAMP and DATE columns in
+var timeLayouts = []string{"2006-01-02 15:04:05.999999999", "2006-01-02", time.RFC3339Nano}
+
+// asTime converts a time value read from the driver, accepting MySQL's text formats and zero dates
+func asTime(src any) (time.Time, error) {
+	var s string
+	switch v := src.(type) {
+	case time.Time:
+		return v, nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return time.Time{}, ErrUnsupportedType
+	}
+
+	if strings.HasPrefix(s, "0000-00-00") {
+		return time.Time{}, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse %q as a time", s)
+}
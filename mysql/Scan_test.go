@@ -0,0 +1,178 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestScanMySQLTextValues checks values the MySQL driver returns as text are converted without going through strings
+func TestScanMySQLTextValues(t *testing.T) {
+	type scanType struct {
+		Id      uint64     `db:"column=id primarykey=yes table=Users"`
+		Balance int64      `db:"column=balance"`
+		Ratio   float64    `db:"column=ratio"`
+		Active  bool       `db:"column=active"`
+		Data    []byte     `db:"column=data"`
+		Dtadded time.Time  `db:"column=dtadded"`
+		Dtseen  *time.Time `db:"column=dtseen"`
+		Note    *string    `db:"column=note"`
+	}
+	db, mock := setupMockDatabase(t)
+	binary := []byte{0x00, 0xff, 0xfe, 0x80}
+	mock.ExpectQuery("SELECT * FROM Users").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "balance", "ratio", "active", "data", "dtadded", "dtseen", "note"}).
+			AddRow([]byte("18446744073709551615"), []byte("-9223372036854775808"), []byte("0.1"), []byte("1"),
+				binary, []byte("2024-01-08 12:30:45"), []byte("2024-01-08 12:30:45.123456"), []byte("note")).
+			AddRow([]byte("1"), nil, nil, nil, nil, []byte("0000-00-00 00:00:00"), nil, nil))
+
+	rows, err := QueryStructWith[scanType](db, "SELECT * FROM Users")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, uint64(18446744073709551615), rows[0].Id)
+	assert.Equal(t, int64(-9223372036854775808), rows[0].Balance)
+	assert.Equal(t, 0.1, rows[0].Ratio)
+	assert.True(t, rows[0].Active)
+	assert.Equal(t, binary, rows[0].Data)
+	assert.Equal(t, time.Date(2024, 1, 8, 12, 30, 45, 0, time.UTC), rows[0].Dtadded)
+	assert.Equal(t, time.Date(2024, 1, 8, 12, 30, 45, 123456000, time.UTC), *rows[0].Dtseen)
+	assert.Equal(t, "note", *rows[0].Note)
+
+	// NULL leaves non-pointer fields at their zero value and pointer fields nil
+	assert.Equal(t, scanType{Id: 1}, rows[1])
+}
+
+func TestScanUnmappedColumns(t *testing.T) {
+	type scanType struct {
+		Id   int    `db:"column=id primarykey=yes table=Users"`
+		Name string `db:"column=name"`
+	}
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name,extra FROM Users").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "extra", "name"}).AddRow(1, "ignored", "Test"))
+
+	rows, err := QueryStructWith[scanType](db, "SELECT id,name,extra FROM Users")
+	assert.NoError(t, err)
+	assert.Equal(t, []scanType{{1, "Test"}}, rows)
+}
+
+func TestScanConversionError(t *testing.T) {
+	type scanType struct {
+		Id   int       `db:"column=id primarykey=yes table=Users"`
+		Seen time.Time `db:"column=seen"`
+	}
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,seen FROM Users").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "seen"}).
+			AddRow([]byte("1"), nil).
+			AddRow([]byte("not a number"), nil))
+
+	rows, err := QueryStructWith[scanType](db, "SELECT id,seen FROM Users")
+	assert.Empty(t, rows)
+	var conversionError *ConversionError
+	if assert.ErrorAs(t, err, &conversionError) {
+		assert.Equal(t, "id", conversionError.Column)
+		assert.Equal(t, 1, conversionError.Row)
+		assert.Equal(t, "[]uint8", conversionError.SourceType)
+		assert.Equal(t, "int", conversionError.TargetType)
+	}
+
+	mock.ExpectQuery("SELECT id,seen FROM Users").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "seen"}).AddRow([]byte("1"), []byte("yesterday")))
+
+	rows, err = QueryStructWith[scanType](db, "SELECT id,seen FROM Users")
+	assert.Empty(t, rows)
+	if assert.ErrorAs(t, err, &conversionError) {
+		assert.Equal(t, "seen", conversionError.Column)
+		assert.Equal(t, 0, conversionError.Row)
+		assert.Equal(t, "time.Time", conversionError.TargetType)
+	}
+}
+
+func TestScanUnsupportedType(t *testing.T) {
+	type scanType struct {
+		Id   int            `db:"column=id primarykey=yes table=Users"`
+		Tags map[string]int `db:"column=tags"`
+	}
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,tags FROM Users").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "tags"}).AddRow(1, nil).AddRow(2, []byte("{}")))
+
+	rows, err := QueryStructWith[scanType](db, "SELECT id,tags FROM Users")
+	assert.Empty(t, rows)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.EqualError(t, err, "column tags, row 1: cannot convert []uint8 to map[string]int: unsupported type")
+}
+
+func TestScanPlanResolvedOnce(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT id,name FROM Users").WillReturnRows(cursorRows())
+
+	cursor, err := db.QueryCursor(context.Background(), "SELECT id,name FROM Users")
+	assert.NoError(t, err)
+	defer cursor.Close()
+
+	var plan *scanPlan
+	for cursor.Next() {
+		var p cursorPerson
+		assert.NoError(t, cursor.Scan(&p))
+		if plan == nil {
+			plan = cursor.plan
+		}
+		assert.Same(t, plan, cursor.plan)
+	}
+	assert.NoError(t, cursor.Err())
+}
+
+// cents is a money amount stored as a decimal string, read through sql.Scanner and written through driver.Valuer
+type cents int64
+
+func (c *cents) Scan(src any) error {
+	f, err := Field{Value: src}.Float()
+	if err != nil {
+		return err
+	}
+	*c = cents(math.Round(f * 100))
+	return nil
+}
+
+func (c cents) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d.%02d", c/100, c%100), nil
+}
+
+// grade is an enum whose Valuer has a pointer receiver
+type grade int
+
+func (g *grade) Value() (driver.Value, error) {
+	return []string{"low", "high"}[*g], nil
+}
+
+func TestScanScanner(t *testing.T) {
+	type scanType struct {
+		Id      int            `db:"column=id primarykey=yes table=Users"`
+		Name    sql.NullString `db:"column=name"`
+		Balance cents          `db:"column=balance"`
+		Credit  *cents         `db:"column=credit"`
+	}
+	db, mock := setupMockDatabase(t)
+	mock.ExpectQuery("SELECT * FROM Users").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "balance", "credit"}).
+			AddRow(1, []byte("Test"), []byte("12.34"), []byte("0.50")).
+			AddRow(2, nil, []byte("0"), nil))
+
+	rows, err := QueryStructWith[scanType](db, "SELECT * FROM Users")
+	assert.NoError(t, err)
+	credit := cents(50)
+	assert.Equal(t, []scanType{
+		{1, sql.NullString{String: "Test", Valid: true}, 1234, &credit},
+		{2, sql.NullString{}, 0, nil},
+	}, rows)
+}
@@ -0,0 +1,179 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// QueryOption changes how the finder helpers build their query. Options are passed among the arguments and are not
+// bound to placeholders.
+type QueryOption int
+
+const (
+	// IncludeDeleted makes Find return the soft deleted rows too
+	IncludeDeleted QueryOption = iota + 1
+)
+
+// Restore generates a parameterized update clearing the softdelete=yes column of the row with the primary key of the
+// structure in the argument, undoing a soft Delete
+func (db *Database) Restore(dbStructure any) (string, []any, error) {
+	table, primaryKeys, err := db.deleteKey(dbStructure)
+	if err != nil {
+		return "", nil, err
+	}
+	softDelete := db.softDeleteField(dbStructure)
+	if softDelete == nil {
+		return "", nil, fmt.Errorf("no softdelete column found in structure")
+	}
+	where, args := keyWhere(primaryKeys)
+	return "UPDATE " + table + " SET " + softDelete.column + "=NULL WHERE " + where + ";", args, nil
+}
+
+// Restore generates a parameterized restore, see Database.Restore
+func (t *Tx) Restore(dbStructure any) (string, []any, error) {
+	return t.db.Restore(dbStructure)
+}
+
+// Purge generates a delete from the table of T of the rows soft deleted before the cutoff, using the package-level DB
+func Purge[T any](before time.Time) (string, []any, error) {
+	return PurgeWith[T](DB, before)
+}
+
+// PurgeWith is Purge using the given handle
+func PurgeWith[T any](db *Database, before time.Time) (string, []any, error) {
+	info, err := softDeleteInfo[T](db)
+	if err != nil {
+		return "", nil, err
+	}
+	if info.softDelete == nil {
+		return "", nil, fmt.Errorf("no softdelete column found in structure")
+	}
+	column := info.softDelete.column
+	return "DELETE FROM " + info.table + " WHERE " + column + " IS NOT NULL AND " + column + "<?;", []any{before}, nil
+}
+
+// Find selects the rows of the table of T matching the where clause against the package-level DB, leaving out the soft
+// deleted rows unless IncludeDeleted is among the arguments. The where clause is a predicate, optionally followed by
+// ORDER BY and LIMIT. An empty where matches every row.
+func Find[T any](where string, args ...any) ([]T, error) {
+	return FindWith[T](DB, where, args...)
+}
+
+// FindWith is Find against the given database or transaction
+func FindWith[T any](h Handle, where string, args ...any) ([]T, error) {
+	return FindContext[T](context.Background(), h, where, args...)
+}
+
+// FindContext is Find against the given handle under ctx
+func FindContext[T any](ctx context.Context, h Handle, where string, args ...any) ([]T, error) {
+	sql, args, err := findSql[T](h.database(), where, args)
+	if err != nil {
+		return make([]T, 0), err
+	}
+	return QueryStructContext[T](ctx, h, sql, args...)
+}
+
+// findSql builds the select run by Find and returns the arguments without the query options
+func findSql[T any](db *Database, where string, args []any) (string, []any, error) {
+	info, err := softDeleteInfo[T](db)
+	if err != nil {
+		return "", nil, err
+	}
+
+	includeDeleted := false
+	parameters := make([]any, 0, len(args))
+	for _, arg := range args {
+		if option, ok := arg.(QueryOption); ok {
+			includeDeleted = includeDeleted || option == IncludeDeleted
+			continue
+		}
+		parameters = append(parameters, arg)
+	}
+
+	where, tail := splitOrderLimit(where)
+	var conditions []string
+	if where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	if info.softDelete != nil && !includeDeleted {
+		conditions = append(conditions, info.softDelete.column+" IS NULL")
+	}
+	sql := "SELECT * FROM " + info.table
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if tail != "" {
+		sql += " " + tail
+	}
+	return sql + ";", parameters, nil
+}
+
+// splitOrderLimit splits a trailing ORDER BY or LIMIT off the where clause, so the predicate can be combined with
+// other conditions. Keywords inside parentheses, quoted strings, quoted identifiers and comments are left alone.
+func splitOrderLimit(where string) (string, string) {
+	depth := 0
+	for i := 0; i < len(where); {
+		c := where[i]
+		end := i + 1
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end = quotedEnd(where, i)
+		case c == '#' || strings.HasPrefix(where[i:], "-- ") || strings.HasPrefix(where[i:], "--\t") || strings.HasPrefix(where[i:], "--\n"):
+			end = commentEnd(where, i+1, "\n")
+		case strings.HasPrefix(where[i:], "/*"):
+			end = commentEnd(where, i+2, "*/")
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && (i == 0 || !isNameByte(where[i-1])) &&
+			(hasKeywords(where[i:], "ORDER", "BY") || hasKeywords(where[i:], "LIMIT")):
+			return strings.TrimSpace(where[:i]), strings.TrimSpace(where[i:])
+		}
+		i = end
+	}
+	return strings.TrimSpace(where), ""
+}
+
+// hasKeywords reports whether sql starts with the words, in any case and separated by whitespace
+func hasKeywords(sql string, words ...string) bool {
+	for _, word := range words {
+		if len(sql) < len(word) || !strings.EqualFold(sql[:len(word)], word) {
+			return false
+		}
+		sql = sql[len(word):]
+		if sql != "" && isNameByte(sql[0]) {
+			return false
+		}
+		sql = strings.TrimLeft(sql, " \t\r\n")
+	}
+	return true
+}
+
+// softDeleteInfo returns the metadata of the struct type T, which must map to a table
+func softDeleteInfo[T any](db *Database) (*structInfo, error) {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct type, got %s", structType)
+	}
+	info := db.structInfo(structType)
+	if info.err != nil {
+		return nil, info.err
+	}
+	if info.table == "" {
+		return nil, fmt.Errorf("no table found in structure")
+	}
+	return info, nil
+}
+
+// softDeleteField returns the softdelete=yes column of the structure, nil when it has none
+func (db *Database) softDeleteField(dbStructure any) *fieldInfo {
+	v := reflect.Indirect(reflect.ValueOf(dbStructure))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return db.structInfo(v.Type()).softDelete
+}
@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type softPerson struct {
+	Id        int        `db:"column=id primarykey=yes table=Users"`
+	Name      string     `db:"column=name"`
+	Dtdeleted *time.Time `db:"column=dtdeleted softdelete=yes"`
+}
+
+func TestSoftDeleteSql(t *testing.T) {
+	db, _, now := stampedDatabase(t)
+
+	sql, args, err := db.Delete(softPerson{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET dtdeleted=? WHERE id=? AND dtdeleted IS NULL;", sql)
+	assert.Equal(t, []any{now, 1}, args)
+
+	sql, args, err = DeleteManyWith(db, []softPerson{{Id: 1}, {Id: 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET dtdeleted=? WHERE id IN (?,?) AND dtdeleted IS NULL;", sql)
+	assert.Equal(t, []any{now, 1, 2}, args)
+
+	sql, args, err = DeleteWhereWith[softPerson](db, "name=? OR id=?", "Test", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET dtdeleted=? WHERE (name=? OR id=?) AND dtdeleted IS NULL;", sql)
+	assert.Equal(t, []any{now, "Test", 3}, args)
+
+	sql, args, err = db.Restore(softPerson{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET dtdeleted=NULL WHERE id=?;", sql)
+	assert.Equal(t, []any{1}, args)
+
+	_, _, err = db.Restore(cursorPerson{Id: 1})
+	assert.EqualError(t, err, "no softdelete column found in structure")
+
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sql, args, err = PurgeWith[softPerson](db, cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM Users WHERE dtdeleted IS NOT NULL AND dtdeleted<?;", sql)
+	assert.Equal(t, []any{cutoff}, args)
+
+	// the deletion time is only written by Delete and Restore
+	deleted := cutoff
+	sql, args, err = db.UpdateArgs(softPerson{1, "Test", &deleted})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=? WHERE id=?;", sql)
+	assert.Equal(t, []any{"Test", 1}, args)
+
+	changed, err := Track(softPerson{1, "Test", nil}).Changed()
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestFind(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+
+	mock.ExpectQuery("SELECT * FROM Users WHERE (name=?) AND dtdeleted IS NULL;").
+		WithArgs("Test").
+		WillReturnRows(cursorRows())
+	people, err := FindWith[softPerson](db, "name=?", "Test")
+	assert.NoError(t, err)
+	assert.Len(t, people, 3)
+
+	mock.ExpectQuery("SELECT * FROM Users WHERE (name=?);").
+		WithArgs("Test").
+		WillReturnRows(cursorRows())
+	_, err = FindWith[softPerson](db, "name=?", IncludeDeleted, "Test")
+	assert.NoError(t, err)
+
+	mock.ExpectQuery("SELECT * FROM Users WHERE dtdeleted IS NULL;").
+		WillReturnRows(cursorRows())
+	_, err = FindWith[softPerson](db, "")
+	assert.NoError(t, err)
+
+	// ORDER BY and LIMIT stay after the softdelete condition
+	mock.ExpectQuery("SELECT * FROM Users WHERE (name=?) AND dtdeleted IS NULL ORDER BY id DESC LIMIT 2;").
+		WithArgs("Test").
+		WillReturnRows(cursorRows())
+	_, err = FindWith[softPerson](db, "name=? ORDER BY id DESC LIMIT 2", "Test")
+	assert.NoError(t, err)
+
+	mock.ExpectQuery("SELECT * FROM Users WHERE dtdeleted IS NULL limit 1;").
+		WillReturnRows(cursorRows())
+	_, err = FindWith[softPerson](db, "limit 1")
+	assert.NoError(t, err)
+
+	// tables without a softdelete column are not filtered
+	mock.ExpectQuery("SELECT * FROM Users;").
+		WillReturnRows(cursorRows())
+	_, err = FindWith[cursorPerson](db, "")
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSplitOrderLimit(t *testing.T) {
+	tests := []struct {
+		where, predicate, tail string
+	}{
+		{"name=?", "name=?", ""},
+		{"name=? OR id=? ORDER BY name LIMIT 10", "name=? OR id=?", "ORDER BY name LIMIT 10"},
+		{"id IN (SELECT id FROM Other ORDER BY id LIMIT 5)", "id IN (SELECT id FROM Other ORDER BY id LIMIT 5)", ""},
+		{"name='order by' AND `limit`=1", "name='order by' AND `limit`=1", ""},
+		{"border_by=1 AND limits=2", "border_by=1 AND limits=2", ""},
+		{"name=?\norder\tby id", "name=?", "order\tby id"},
+	}
+	for _, test := range tests {
+		predicate, tail := splitOrderLimit(test.where)
+		assert.Equal(t, test.predicate, predicate, test.where)
+		assert.Equal(t, test.tail, tail, test.where)
+	}
+}
+
+func TestSoftDeleteNullable(t *testing.T) {
+	db, _ := setupMockDatabase(t)
+	_, _, err := db.InsertArgs(struct {
+		Id        int       `db:"column=id primarykey=yes table=Users"`
+		Dtdeleted time.Time `db:"column=dtdeleted softdelete=yes"`
+	}{})
+	assert.EqualError(t, err, "softdelete column dtdeleted must be nullable, e.g. *time.Time or sql.NullTime")
+
+	type nullTimePerson struct {
+		Id        int          `db:"column=id primarykey=yes table=Users"`
+		Dtdeleted sql.NullTime `db:"column=dtdeleted softdelete=yes"`
+	}
+	info := getStructInfo(reflect.TypeOf(nullTimePerson{}))
+	assert.NoError(t, info.err)
+	assert.NotNil(t, info.softDelete)
+}
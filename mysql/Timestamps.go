@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Fields tagged autocreate=yes or autoupdate=yes are filled from the Database's Clock: autocreate columns on insert
+// when they are zero, autoupdate columns on every insert and update. Updates never write the autocreate columns.
+
+// now returns the time to write to the timestamp columns, in the time zone the driver writes bound times in, so the
+// inline and parameterized statements store the same wall-clock time
+func (db *Database) now() time.Time {
+	clock := time.Now
+	if db != nil && db.Clock != nil {
+		clock = db.Clock
+	}
+	return clock().In(db.location())
+}
+
+// dsnLocation is the location parsed from a DSN
+type dsnLocation struct {
+	dsn string
+	loc *time.Location
+}
+
+// location returns the loc parameter of the DSN, which the driver converts bound times to, UTC when it has none. The
+// DSN is parsed once and again only after it changes.
+func (db *Database) location() *time.Location {
+	if db == nil {
+		return time.UTC
+	}
+	dsn := db.DSN
+	if cached := db.loc.Load(); cached != nil && cached.dsn == dsn {
+		return cached.loc
+	}
+	loc := time.UTC
+	if config, err := mysql.ParseDSN(dsn); err == nil && config.Loc != nil {
+		loc = config.Loc
+	}
+	db.loc.Store(&dsnLocation{dsn, loc})
+	return loc
+}
+
+// stampInsert fills the timestamp columns of an insert
+func (db *Database) stampInsert(columns []columnValue) {
+	var now time.Time
+	for i, c := range columns {
+		if c.field.autoUpdate || (c.field.autoCreate && isZeroValue(c.value)) {
+			if now.IsZero() {
+				now = db.now()
+			}
+			columns[i].value = now
+		}
+	}
+}
+
+// stampUpdate fills the autoupdate columns of an update and leaves out the autocreate and softdelete ones
+func (db *Database) stampUpdate(columns []columnValue) []columnValue {
+	var now time.Time
+	stamped := make([]columnValue, 0, len(columns))
+	for _, c := range columns {
+		if c.field.autoCreate || c.field.softDelete {
+			continue
+		}
+		if c.field.autoUpdate {
+			if now.IsZero() {
+				now = db.now()
+			}
+			c.value = now
+		}
+		stamped = append(stamped, c)
+	}
+	return stamped
+}
+
+// setStamped copies the timestamps written for the columns back into the structure dbStructure points to
+func setStamped(dbStructure any, columns []columnValue) {
+	v := reflect.ValueOf(dbStructure)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	for _, c := range columns {
+		t, ok := c.value.(time.Time)
+		if !ok || !(c.field.autoCreate || c.field.autoUpdate) {
+			continue
+		}
+		field := v.Elem().FieldByIndex(c.field.index)
+		switch field.Type() {
+		case timeType:
+			field.Set(reflect.ValueOf(t))
+		case reflect.PointerTo(timeType):
+			field.Set(reflect.ValueOf(&t))
+		}
+	}
+}
+
+// isZeroValue reports whether a column value is nil or the zero value of its type
+func isZeroValue(value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
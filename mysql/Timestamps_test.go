@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type stampedPerson struct {
+	Id        int        `db:"column=id primarykey=yes table=Users"`
+	Name      string     `db:"column=name"`
+	Dtadded   time.Time  `db:"column=dtadded autocreate=yes"`
+	Dtupdated *time.Time `db:"column=dtupdated autoupdate=yes"`
+}
+
+func stampedDatabase(t *testing.T) (*Database, sqlmock.Sqlmock, time.Time) {
+	db, mock := setupMockDatabase(t)
+	db.DSN = "test:test@tcp(localhost:3306)/test?loc=Pacific%2FAuckland"
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	db.Clock = func() time.Time { return now }
+	return db, mock, now.In(db.location())
+}
+
+func TestTimestampsSql(t *testing.T) {
+	db, _, now := stampedDatabase(t)
+	added := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sql, args, err := db.InsertArgs(stampedPerson{Name: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,dtadded,dtupdated) VALUES (?,?,?);", sql)
+	assert.Equal(t, []any{"Test", now, now}, args)
+
+	// a creation time that is already set is kept
+	_, args, err = db.InsertArgs(stampedPerson{Name: "Test", Dtadded: added})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"Test", added, now}, args)
+
+	sql, err = db.Insert(stampedPerson{Name: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(name,dtadded,dtupdated) VALUES (X'54657374','2024-06-01 21:00:00','2024-06-01 21:00:00');", sql)
+
+	_, args, err = InsertManyArgsWith(db, []stampedPerson{{Name: "a"}, {Name: "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"a", now, now, "b", now, now}, args)
+
+	// the creation time is never updated
+	sql, args, err = db.UpdateArgs(stampedPerson{1, "Test", added, nil})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=?,dtupdated=? WHERE id=?;", sql)
+	assert.Equal(t, []any{"Test", now, 1}, args)
+
+	sql, _, err = db.Upsert(stampedPerson{1, "Test", added, nil})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Users(id,name,dtadded,dtupdated) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE name=VALUES(name),dtupdated=VALUES(dtupdated);", sql)
+
+	// a row of only autocreate columns has nothing to update on a duplicate key
+	_, _, err = db.Upsert(struct {
+		Id      int       `db:"column=id primarykey=yes table=Users"`
+		Dtadded time.Time `db:"column=dtadded autocreate=yes"`
+	}{Id: 1})
+	assert.EqualError(t, err, "no columns to update on a duplicate key, use UpsertIgnore")
+
+	// only a timestamp differing is not a change
+	sql, _, err = db.UpdateChanged(stampedPerson{1, "Test", added, nil}, stampedPerson{1, "Test", added, &added})
+	assert.NoError(t, err)
+	assert.Empty(t, sql)
+}
+
+func TestSaveTimestamps(t *testing.T) {
+	db, mock, now := stampedDatabase(t)
+	mock.ExpectExec("INSERT INTO Users(name,dtadded,dtupdated) VALUES (?,?,?);").
+		WithArgs("Test", now, now).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	person := stampedPerson{Name: "Test"}
+	_, _, err := db.Save(&person)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, person.Id)
+	assert.Equal(t, now, person.Dtadded)
+	if assert.NotNil(t, person.Dtupdated) {
+		assert.Equal(t, now, *person.Dtupdated)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTimestampsTimeZone tests the inline and parameterized inserts store the same wall-clock time, the driver writing
+// bound times in the DSN's loc
+func TestTimestampsTimeZone(t *testing.T) {
+	db, _, _ := stampedDatabase(t)
+	db.Clock = func() time.Time { return time.Date(2024, 6, 1, 9, 0, 0, 0, time.FixedZone("CEST", 2*60*60)) }
+
+	inline, err := db.Insert(stampedPerson{Name: "Test"})
+	assert.NoError(t, err)
+	_, args, err := db.InsertArgs(stampedPerson{Name: "Test"})
+	assert.NoError(t, err)
+
+	stored := args[1].(time.Time).In(db.location()).Format(time.DateTime)
+	assert.Equal(t, "2024-06-01 19:00:00", stored)
+	assert.Contains(t, inline, "'"+stored+"'")
+
+	// the DSN is parsed once, not for every stamped row
+	cached := db.loc.Load()
+	db.now()
+	assert.Same(t, cached, db.loc.Load())
+
+	// without a loc, the driver and the stamps use UTC
+	db.DSN = "test/test"
+	inline, err = db.Insert(stampedPerson{Name: "Test"})
+	assert.NoError(t, err)
+	assert.Contains(t, inline, "'2024-06-01 07:00:00'")
+	assert.Equal(t, time.UTC, db.loc.Load().loc)
+}
@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// UpdateChanged generates a parameterized update of only the columns whose values differ between original and
+// modified, two copies of the same structure type, e.g. a row loaded with QueryStruct and the same row after editing.
+// The row is matched on the primary key columns, and the version column, of original, and the two copies must have the
+// same primary key. When nothing changed, the SQL is empty and nothing should run.
+func (db *Database) UpdateChanged(original any, modified any) (string, []any, error) {
+	originalType := reflect.Indirect(reflect.ValueOf(original)).Type()
+	modifiedType := reflect.Indirect(reflect.ValueOf(modified)).Type()
+	if originalType != modifiedType {
+		return "", nil, fmt.Errorf("cannot compare %s with %s", originalType, modifiedType)
+	}
+
+	table, primaryKeys, before, err := db.updateColumns(original)
+	if err != nil {
+		return "", nil, err
+	}
+	_, modifiedKeys, after, err := db.updateColumns(modified)
+	if err != nil {
+		return "", nil, err
+	}
+	for i, c := range primaryKeys {
+		if !valuesEqual(c.value, modifiedKeys[i].value) {
+			return "", nil, fmt.Errorf("primary key %s differs between the original and the modified structure", c.column)
+		}
+	}
+
+	var changed []columnValue
+	for i, c := range after {
+		if !c.field.version && !c.field.autoUpdate && !valuesEqual(before[i].value, c.value) {
+			changed = append(changed, c)
+		}
+	}
+	if len(changed) == 0 {
+		return "", nil, nil
+	}
+	for _, c := range after {
+		if c.field.autoUpdate {
+			changed = append(changed, c)
+		}
+	}
+	return updateSql(table, primaryKeys, before, changed)
+}
+
+// UpdateChanged generates a parameterized update of the changed columns, see Database.UpdateChanged
+func (t *Tx) UpdateChanged(original any, modified any) (string, []any, error) {
+	return t.db.UpdateChanged(original, modified)
+}
+
+// Tracked holds a structure together with a snapshot of it, so only the columns changed since the snapshot are
+// written back. Edit Value, then call Update.
+type Tracked[T any] struct {
+	Value    T
+	original T
+	h        Handle
+}
+
+// Track starts tracking changes to value, typically a row just loaded with QueryStruct, to write them back through
+// the package-level DB
+func Track[T any](value T) *Tracked[T] {
+	return TrackWith(DB, value)
+}
+
+// TrackWith is Track writing back through the given database or transaction, whose naming strategy and clock apply
+func TrackWith[T any](h Handle, value T) *Tracked[T] {
+	t := &Tracked[T]{Value: value, h: h}
+	t.Reset()
+	return t
+}
+
+// Reset takes a new snapshot of Value, forgetting the changes made so far
+func (t *Tracked[T]) Reset() {
+	t.original = snapshot(t.Value)
+}
+
+// Changed returns the columns whose values changed since the snapshot
+func (t *Tracked[T]) Changed() ([]string, error) {
+	db := t.h.database()
+	_, _, before, err := db.structColumns(t.original)
+	if err != nil {
+		return nil, err
+	}
+	_, _, after, err := db.structColumns(t.Value)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for i, c := range after {
+		if !c.field.version && !c.field.autoCreate && !c.field.autoUpdate && !c.field.softDelete && !valuesEqual(before[i].value, c.value) {
+			changed = append(changed, c.column)
+		}
+	}
+	return changed, nil
+}
+
+// UpdateArgs generates the parameterized update of the changed columns, see Database.UpdateChanged
+func (t *Tracked[T]) UpdateArgs() (string, []any, error) {
+	return t.h.database().UpdateChanged(t.original, t.Value)
+}
+
+// Update writes the changed columns through the tracking handle and takes a new snapshot. No statement is run when
+// nothing changed.
+func (t *Tracked[T]) Update() (int64, error) {
+	return t.UpdateContext(context.Background(), t.h)
+}
+
+// UpdateContext writes the changed columns through the given handle under ctx, e.g. a transaction, and takes a new
+// snapshot
+func (t *Tracked[T]) UpdateContext(ctx context.Context, h Handle) (int64, error) {
+	sql, args, err := h.database().UpdateChanged(t.original, t.Value)
+	if err != nil || sql == "" {
+		return 0, err
+	}
+	_, rowsAffected, err := h.ExecuteContext(ctx, sql, args...)
+	if err != nil {
+		return rowsAffected, err
+	}
+	if err := h.database().checkVersion(&t.Value, rowsAffected); err != nil {
+		return rowsAffected, err
+	}
+	t.Reset()
+	return rowsAffected, nil
+}
+
+// snapshot copies value along with what its pointer and byte slice fields refer to, so editing those through the
+// original doesn't change the copy
+func snapshot[T any](value T) T {
+	v := reflect.ValueOf(&value).Elem()
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(v.Elem())
+		v.Set(copied)
+		v = copied.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return value
+	}
+
+	for _, fi := range getStructInfo(v.Type()).fields {
+		field := v.FieldByIndex(fi.index)
+		if !field.CanSet() {
+			continue
+		}
+		switch {
+		case field.Kind() == reflect.Pointer && !field.IsNil():
+			copied := reflect.New(field.Type().Elem())
+			copied.Elem().Set(field.Elem())
+			field.Set(copied)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8 && !field.IsNil():
+			field.Set(reflect.AppendSlice(reflect.MakeSlice(field.Type(), 0, field.Len()), field))
+		}
+	}
+	return value
+}
+
+// valuesEqual compares two column values, treating times at the same instant as equal
+func valuesEqual(a, b any) bool {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() == reflect.Pointer && bv.Kind() == reflect.Pointer && av.Type() == bv.Type() && !av.IsNil() && !bv.IsNil() {
+		return valuesEqual(av.Elem().Interface(), bv.Elem().Interface())
+	}
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return reflect.DeepEqual(a, b)
+}
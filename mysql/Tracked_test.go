@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type trackedPerson struct {
+	Id      int        `db:"column=id primarykey=yes table=Users"`
+	Name    string     `db:"column=name"`
+	Status  int        `db:"column=status"`
+	Nick    *string    `db:"column=nick"`
+	Dtadded time.Time  `db:"column=dtadded"`
+	Dtseen  *time.Time `db:"column=dtseen"`
+}
+
+func TestUpdateChanged(t *testing.T) {
+	New("", nil)
+	seen := time.Date(2024, 1, 8, 12, 30, 45, 0, time.UTC)
+	original := trackedPerson{1, "Test", 1, nil, seen, &seen}
+	modified := original
+	modified.Status = 2
+	// the same instant in another location is not a change
+	modified.Dtadded = seen.In(time.FixedZone("NZDT", 13*60*60))
+
+	sql, args, err := DB.UpdateChanged(original, modified)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET status=? WHERE id=?;", sql)
+	assert.Equal(t, []any{2, 1}, args)
+
+	sql, args, err = DB.UpdateChanged(original, original)
+	assert.NoError(t, err)
+	assert.Empty(t, sql)
+	assert.Empty(t, args)
+
+	_, _, err = DB.UpdateChanged(original, cursorPerson{})
+	assert.EqualError(t, err, "cannot compare mysql.trackedPerson with mysql.cursorPerson")
+
+	other := original
+	other.Id = 2
+	_, _, err = DB.UpdateChanged(original, other)
+	assert.EqualError(t, err, "primary key id differs between the original and the modified structure")
+}
+
+// TestTrackWith tests the tracking handle's naming strategy is used, not the package-level DB's
+func TestTrackWith(t *testing.T) {
+	New("", nil)
+	db, mock := setupMockDatabase(t)
+	db.Naming = SnakeCaseNaming
+	mock.ExpectExec("UPDATE user_account SET first_name=? WHERE id=?;").
+		WithArgs("Other", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tracked := TrackWith(db, UserAccount{ID: 1, FirstName: "Test"})
+	tracked.Value.FirstName = "Other"
+	changed, err := tracked.Changed()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first_name"}, changed)
+	_, err = tracked.Update()
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTracked(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("UPDATE Users SET name=?,nick=? WHERE id=?;").
+		WithArgs("Other", "o", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	nick := "t"
+	tracked := Track(trackedPerson{Id: 1, Name: "Test", Nick: &nick})
+
+	changed, err := tracked.Changed()
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+	rowsAffected, err := tracked.UpdateContext(context.Background(), db)
+	assert.NoError(t, err)
+	assert.Zero(t, rowsAffected)
+
+	// edits through a shared pointer are seen as changes
+	tracked.Value.Name = "Other"
+	*tracked.Value.Nick = "o"
+	changed, err = tracked.Changed()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "nick"}, changed)
+
+	rowsAffected, err = tracked.UpdateContext(context.Background(), db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	// the snapshot is taken again after a successful update
+	changed, err = tracked.Changed()
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,200 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Tx is a database transaction. It exposes the same surface as Database, so a group of Save, Execute and
+// RecordUpdate calls can be committed or rolled back together.
+type Tx struct {
+	db         *Database
+	tx         *sql.Tx
+	savepoints int
+}
+
+// Begin starts a transaction
+func (db *Database) Begin() (*Tx, error) {
+	return db.BeginTx(context.Background(), nil)
+}
+
+// BeginTx starts a transaction under ctx with the given options, which may be nil
+func (db *Database) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	DatabaseConnection, err := db.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := DatabaseConnection.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{db: db, tx: tx}, nil
+}
+
+// WithTransaction runs fn inside a transaction. The transaction is committed when fn returns nil, and rolled back
+// when it returns an error or panics (the panic is then re-raised).
+func (db *Database) WithTransaction(fn func(tx *Tx) error) error {
+	return db.WithTransactionContext(context.Background(), fn)
+}
+
+// WithTransactionContext is WithTransaction with the transaction run under ctx
+func (db *Database) WithTransactionContext(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// WithTransaction runs fn inside a savepoint of this transaction. The savepoint is released when fn returns nil, and
+// rolled back to when it returns an error or panics, leaving the outer transaction open either way.
+func (t *Tx) WithTransaction(fn func(tx *Tx) error) error {
+	return t.WithTransactionContext(context.Background(), fn)
+}
+
+// WithTransactionContext is WithTransaction with the savepoint statements run under ctx
+func (t *Tx) WithTransactionContext(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	t.savepoints++
+	savepoint := fmt.Sprintf("sp_%d", t.savepoints)
+
+	if _, err = t.tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint)
+			panic(p)
+		}
+	}()
+
+	if err = fn(t); err != nil {
+		if _, rollbackErr := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	_, err = t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint)
+	return err
+}
+
+// Commit commits the transaction
+func (t *Tx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback aborts the transaction
+func (t *Tx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func (t *Tx) database() *Database {
+	return t.db
+}
+
+func (t *Tx) Execute(sql string, parameters ...any) (int64, int64, error) {
+	return t.ExecuteContext(context.Background(), sql, parameters...)
+}
+
+// ExecuteContext runs a statement inside the transaction under ctx
+func (t *Tx) ExecuteContext(ctx context.Context, sql string, parameters ...any) (int64, int64, error) {
+	return t.db.execute(ctx, t.tx, sql, parameters...)
+}
+
+func (t *Tx) Query(sql string, parameters ...any) ([]Record, error) {
+	return t.QueryContext(context.Background(), sql, parameters...)
+}
+
+// QueryContext runs a query inside the transaction under ctx
+func (t *Tx) QueryContext(ctx context.Context, sql string, parameters ...any) ([]Record, error) {
+	return t.db.query(ctx, t.tx, sql, parameters...)
+}
+
+// QueryCursor runs a query inside the transaction and returns a Cursor over its rows
+func (t *Tx) QueryCursor(ctx context.Context, sql string, parameters ...any) (*Cursor, error) {
+	return t.db.queryCursor(ctx, t.tx, sql, parameters...)
+}
+
+// QueryEach runs a query inside the transaction and calls fn for each row as it is read, see Database.QueryEach
+func (t *Tx) QueryEach(sql string, fn func(Record) error, parameters ...any) error {
+	return t.QueryEachContext(context.Background(), sql, fn, parameters...)
+}
+
+// QueryEachContext is QueryEach with the query run under ctx
+func (t *Tx) QueryEachContext(ctx context.Context, sql string, fn func(Record) error, parameters ...any) error {
+	return queryEach(ctx, t, sql, fn, parameters...)
+}
+
+// Insert generates an SQL query based on the db column tags provided in the structure of the argument
+func (t *Tx) Insert(dbStructure any) (string, error) {
+	return t.db.Insert(dbStructure)
+}
+
+// Update generates an SQL query based on the db column tags provided in the structure of the argument
+func (t *Tx) Update(dbStructure any) (string, error) {
+	return t.db.Update(dbStructure)
+}
+
+// InsertArgs generates a parameterized insert, see Database.InsertArgs
+func (t *Tx) InsertArgs(dbStructure any) (string, []any, error) {
+	return t.db.InsertArgs(dbStructure)
+}
+
+// UpdateArgs generates a parameterized update, see Database.UpdateArgs
+func (t *Tx) UpdateArgs(dbStructure any) (string, []any, error) {
+	return t.db.UpdateArgs(dbStructure)
+}
+
+// Save inserts or updates the structure inside the transaction, see Database.Save
+func (t *Tx) Save(dbStructure any, primaryKeyValue ...any) (lastInsertedID, rowsAffected int64, err error) {
+	return t.SaveContext(context.Background(), dbStructure, primaryKeyValue...)
+}
+
+// SaveContext is Save with the statement run under ctx
+func (t *Tx) SaveContext(ctx context.Context, dbStructure any, primaryKeyValue ...any) (lastInsertedID, rowsAffected int64, err error) {
+	return save(ctx, t, dbStructure, primaryKeyValue...)
+}
+
+// InsertExec inserts the structure inside the transaction, see Database.InsertExec
+func (t *Tx) InsertExec(dbStructure any) (lastInsertedID, rowsAffected int64, err error) {
+	return t.InsertExecContext(context.Background(), dbStructure)
+}
+
+// InsertExecContext is InsertExec with the statement run under ctx
+func (t *Tx) InsertExecContext(ctx context.Context, dbStructure any) (lastInsertedID, rowsAffected int64, err error) {
+	return insertExec(ctx, t, dbStructure)
+}
+
+func (t *Tx) RecordInsert(RecordToInsert Record, InsertTable string) (int64, error) {
+	return t.RecordInsertContext(context.Background(), RecordToInsert, InsertTable)
+}
+
+// RecordInsertContext is RecordInsert with the statement run under ctx
+func (t *Tx) RecordInsertContext(ctx context.Context, RecordToInsert Record, InsertTable string) (int64, error) {
+	return recordInsert(ctx, t, RecordToInsert, InsertTable)
+}
+
+func (t *Tx) RecordUpdate(RecordToUpdate Record, UpdateTable string, UpdateColumn string, UpdateColumnValue string) (int64, error) {
+	return t.RecordUpdateContext(context.Background(), RecordToUpdate, UpdateTable, UpdateColumn, UpdateColumnValue)
+}
+
+// RecordUpdateContext is RecordUpdate with the statement run under ctx
+func (t *Tx) RecordUpdateContext(ctx context.Context, RecordToUpdate Record, UpdateTable string, UpdateColumn string, UpdateColumnValue string) (int64, error) {
+	return recordUpdate(ctx, t, RecordToUpdate, UpdateTable, UpdateColumn, UpdateColumnValue)
+}
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxCommit(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO Users(name,status) VALUES (?,?);`).WithArgs("Test", 31).WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("UPDATE Users SET status=2 WHERE id=7").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	id, _, err := tx.Save(SavePersonTime{0, "Test", time.Now(), 31}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	_, _, err = tx.Execute("UPDATE Users SET status=2 WHERE id=7")
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxQueryStruct(t *testing.T) {
+	type Person struct {
+		Id   int    `db:"column=id primarykey=yes table=Users"`
+		Name string `db:"column=name"`
+	}
+	db, mock := setupMockDatabase(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id,name FROM Users FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test"))
+	mock.ExpectRollback()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	people, err := QueryStructWith[Person](tx, "SELECT id,name FROM Users FOR UPDATE")
+	assert.NoError(t, err)
+	assert.Equal(t, []Person{{1, "Test"}}, people)
+	assert.NoError(t, tx.Rollback())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransaction(t *testing.T) {
+	t.Run("Commit", func(t *testing.T) {
+		db, mock := setupMockDatabase(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM Users").WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		err := db.WithTransaction(func(tx *Tx) error {
+			_, _, err := tx.Execute("DELETE FROM Users")
+			return err
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rollback On Error", func(t *testing.T) {
+		db, mock := setupMockDatabase(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM Users").WillReturnError(errors.New("dummy error"))
+		mock.ExpectRollback()
+
+		err := db.WithTransaction(func(tx *Tx) error {
+			_, _, err := tx.Execute("DELETE FROM Users")
+			return err
+		})
+		assert.EqualError(t, err, "dummy error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rollback On Panic", func(t *testing.T) {
+		db, mock := setupMockDatabase(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = db.WithTransaction(func(tx *Tx) error {
+				panic("boom")
+			})
+		})
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nested Savepoints", func(t *testing.T) {
+		db, mock := setupMockDatabase(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM a").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM b").WillReturnError(errors.New("dummy error"))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := db.WithTransaction(func(tx *Tx) error {
+			err := tx.WithTransaction(func(tx *Tx) error {
+				_, _, err := tx.Execute("DELETE FROM a")
+				return err
+			})
+			assert.NoError(t, err)
+
+			// the inner failure only unwinds its own savepoint
+			err = tx.WithTransaction(func(tx *Tx) error {
+				_, _, err := tx.Execute("DELETE FROM b")
+				return err
+			})
+			assert.EqualError(t, err, "dummy error")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,82 +1,103 @@
 package mysql
 
 import (
-	"errors"
 	"fmt"
-	"reflect"
 	"strings"
-	"time"
 )
 
+// Update generates an SQL query based on the db column tags provided in the structure of the argument, with the
+// values written inline. Prefer UpdateArgs, which binds the values as parameters.
 func (db *Database) Update(dbStructure any) (string, error) {
+	table, primaryKeys, columns, err := db.updateColumns(dbStructure)
+	if err != nil {
+		return "", err
+	}
 
-	t := reflect.TypeOf(dbStructure)
-	UpdateTable := ""
-	buildsql := ""
-	UpdateColumn := ""
-	UpdateValue := ""
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag := field.Tag.Get("db")
-		dbStructureMap := decodeTag(tag)
-
-		if reflect.ValueOf(dbStructure).Field(i).CanInterface() {
-			value := reflect.ValueOf(dbStructure).Field(i).Interface()
-			// l.INFO("%d. Value='%v'  %v (%v), tag: '%v'\n", i+1, value, field.Name, field.Type.Name(), tag)
-
-			// TODO: Need to look at way for this to happen and not though an error
-			if dbStructureMap["column"] == "" {
-				return "", errors.New("no column name specified for field " + field.Name)
-			}
-
-			if dbStructureMap["primarykey"] == "yes" {
-				// l.INFO("Primary Key Found: %s", dbStructureMap["table"])
-				UpdateColumn = dbStructureMap["column"]
-				UpdateValue = fmt.Sprintf("%v", value)
-			}
+	set := make([]string, 0, len(columns))
+	var version *columnValue
+	for i, c := range columns {
+		if c.field.version {
+			version = &columns[i]
+			set = append(set, c.column+"="+c.column+"+1")
+			continue
+		}
+		literal, err := sqlLiteral(c.value)
+		if err != nil {
+			return "", fmt.Errorf("column %s: %w", c.column, err)
+		}
+		set = append(set, c.column+"="+literal)
+	}
+	matched := primaryKeys
+	if version != nil {
+		matched = append(matched[:len(matched):len(matched)], *version)
+	}
+	where := make([]string, 0, len(matched))
+	for _, c := range matched {
+		literal, err := sqlLiteral(c.value)
+		if err != nil {
+			return "", fmt.Errorf("column %s: %w", c.column, err)
+		}
+		where = append(where, c.column+"="+literal)
+	}
 
-			if dbStructureMap["table"] != "" {
-				UpdateTable = dbStructureMap["table"]
-			}
+	return "UPDATE " + table + " SET " + strings.Join(set, ",") + " WHERE " + strings.Join(where, " AND ") + ";", nil
+}
 
-			if dbStructureMap["omit"] != "yes" && dbStructureMap["primarykey"] != "yes" {
-				buildsql = buildsql + dbStructureMap["column"] + "="
+// UpdateArgs generates a parameterized update based on the db column tags provided in the structure of the argument,
+// returning the SQL with ? placeholders and the values to bind to them. A version=yes column is incremented and
+// matched in the WHERE clause, so the update affects no rows when the row was changed since it was read.
+func (db *Database) UpdateArgs(dbStructure any) (string, []any, error) {
+	table, primaryKeys, columns, err := db.updateColumns(dbStructure)
+	if err != nil {
+		return "", nil, err
+	}
+	return updateSql(table, primaryKeys, columns, columns)
+}
 
-				switch field.Type.Name() {
-				case "uint", "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int", "int32", "int64":
-					buildsql = buildsql + fmt.Sprintf("%v", value) + ","
-				case "string":
-					buildsql = buildsql + hexRepresentation(value.(string)) + ","
-				case "float32", "float64":
-					buildsql = buildsql + fmt.Sprintf("%v", value) + ","
-				case "bool":
-					buildsql = buildsql + fmt.Sprintf("%v", value) + ","
-				case "Time":
-					buildsql = buildsql + fmt.Sprintf("'%s'", value.(time.Time).Format("2006-01-02 15:04:05")) + ","
-				default:
-					db.Logger.With("type", field.Type.Name()).With("value", value).Error("type error")
-					buildsql = buildsql + "'" + value.(string) + "',"
-				}
-			}
+// updateSql renders a parameterized update of the changed columns, matching the row on every primary key column and,
+// when columns has one, the version column
+func updateSql(table string, primaryKeys []columnValue, columns []columnValue, changed []columnValue) (string, []any, error) {
+	set := make([]string, 0, len(changed)+1)
+	args := make([]any, 0, len(changed)+2)
+	for _, c := range changed {
+		if c.field.version {
+			continue
 		}
+		set = append(set, c.column+"=?")
+		args = append(args, c.value)
 	}
-	// Get Rid of Trailing Comma
 
-	if UpdateTable == "" {
-		return "", fmt.Errorf("no table found in structure")
+	where, keyArgs := keyWhere(primaryKeys)
+	args = append(args, keyArgs...)
+	for _, c := range columns {
+		if c.field.version {
+			set = append(set, c.column+"="+c.column+"+1")
+			where += " AND " + c.column + "=?"
+			args = append(args, c.value)
+		}
 	}
 
-	if buildsql == "" {
-		return "", fmt.Errorf("no non-primary key and non-omitted fields found in structure")
+	return "UPDATE " + table + " SET " + strings.Join(set, ",") + " WHERE " + where + ";", args, nil
+}
+
+// updateColumns returns the table, primary key columns and the non-primary key, non-omitted columns of the structure, with the
+// autoupdate columns filled in and the autocreate columns left out
+func (db *Database) updateColumns(dbStructure any) (string, []columnValue, []columnValue, error) {
+	table, primaryKeys, columns, err := db.structColumns(dbStructure)
+	if err != nil {
+		return "", nil, nil, err
 	}
 
-	if UpdateColumn == "" {
-		return "", fmt.Errorf("no primary key set, unable to set a where clause")
+	if table == "" {
+		return "", nil, nil, fmt.Errorf("no table found in structure")
 	}
 
-	buildsql = strings.TrimSuffix(buildsql, ",")
-	SQL := "UPDATE " + UpdateTable + " SET " + buildsql + " WHERE " + UpdateColumn + "=" + UpdateValue + ";"
+	if len(columns) == 0 {
+		return "", nil, nil, fmt.Errorf("no non-primary key and non-omitted fields found in structure")
+	}
 
-	return SQL, nil
+	if len(primaryKeys) == 0 {
+		return "", nil, nil, fmt.Errorf("no primary key set, unable to set a where clause")
+	}
+	return table, primaryKeys, db.stampUpdate(columns), nil
 }
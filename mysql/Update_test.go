@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
@@ -20,6 +21,12 @@ type UpdatePersonTime struct {
 	Dtadded time.Time `db:"column=dtadded"`
 }
 
+type tenantPerson struct {
+	TenantId int    `db:"column=tenant_id primarykey=yes table=Users"`
+	Id       int    `db:"column=id primarykey=yes"`
+	Name     string `db:"column=name"`
+}
+
 func generateUpdatePerson[StatusType uint | uint8 | uint16 | uint32 | uint64 | int | int8 | int16 | int32 | int64 | float32 | float64 | string | bool](value StatusType) UpdatePerson[StatusType] {
 	return UpdatePerson[StatusType]{
 		0, "Test", time.Now(), value,
@@ -157,3 +164,58 @@ func TestNoFieldsUpdate(t *testing.T) {
 	assert.EqualError(t, err, "no non-primary key and non-omitted fields found in structure")
 	assert.Empty(t, sql)
 }
+
+func TestUpdateArgs(t *testing.T) {
+	New("", nil)
+	sql, args, err := DB.UpdateArgs(generateUpdatePerson(int64(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=?,status=? WHERE id=?;", sql)
+	assert.Equal(t, []any{"Test", int64(1), 0}, args)
+
+	_, _, err = DB.UpdateArgs(struct {
+		Id   int    `db:"column=id table=Users"`
+		Name string `db:"column=name"`
+	}{1, "Test"})
+	assert.EqualError(t, err, "no primary key set, unable to set a where clause")
+}
+
+// TestUpdateStringPrimaryKey checks the primary key is rendered as a literal, not interpolated with %v
+func TestUpdateStringPrimaryKey(t *testing.T) {
+	type testType struct {
+		Id   string `db:"column=id primarykey=yes table=Users"`
+		Name string `db:"column=name"`
+	}
+	sql, err := DB.Update(testType{"1 OR 1=1", "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=X'54657374' WHERE id=X'31204f5220313d31';", sql)
+}
+
+func TestUpdateValuer(t *testing.T) {
+	type testType struct {
+		Id      int            `db:"column=id primarykey=yes table=Users"`
+		Name    sql.NullString `db:"column=name"`
+		Balance cents          `db:"column=balance"`
+	}
+	entry := testType{1, sql.NullString{String: "Test", Valid: true}, 250}
+
+	query, err := DB.Update(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=X'54657374',balance=X'322e3530' WHERE id=1;", query)
+
+	query, args, err := DB.UpdateArgs(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=?,balance=? WHERE id=?;", query)
+	assert.Equal(t, []any{"Test", "2.50", 1}, args)
+}
+
+func TestUpdateCompositeKey(t *testing.T) {
+	New("", nil)
+	sql, err := DB.Update(tenantPerson{7, 3, "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=X'54657374' WHERE tenant_id=7 AND id=3;", sql)
+
+	sql, args, err := DB.UpdateArgs(tenantPerson{7, 3, "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Users SET name=? WHERE tenant_id=? AND id=?;", sql)
+	assert.Equal(t, []any{"Test", 7, 3}, args)
+}
@@ -0,0 +1,144 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UpsertMode chooses the statement an upsert is written as
+type UpsertMode int
+
+const (
+	// UpsertUpdate writes INSERT ... ON DUPLICATE KEY UPDATE, updating the existing row
+	UpsertUpdate UpsertMode = iota
+	// UpsertIgnore writes INSERT IGNORE, keeping the existing row
+	UpsertIgnore
+	// UpsertReplace writes REPLACE, deleting the existing row and inserting the new one
+	UpsertReplace
+)
+
+// UpsertOptions configures Upsert and UpsertMany
+type UpsertOptions struct {
+	Mode UpsertMode
+	// Columns limits the columns updated on a duplicate key. By default every non-primary key, non-omitted column is.
+	Columns []string
+}
+
+// Upsert generates a parameterized insert based on the db column tags provided in the structure of the argument that
+// updates the row instead when its primary or a unique key already exists. Unlike InsertArgs, the primary key column
+// is written, so natural keys work. At most one UpsertOptions is used.
+func (db *Database) Upsert(dbStructure any, options ...UpsertOptions) (string, []any, error) {
+	return UpsertManyWith(db, []any{dbStructure}, options...)
+}
+
+// UpsertMany generates a parameterized multi-row upsert for the elements in the argument, using the package-level DB
+func UpsertMany[T any](dbStructures []T, options ...UpsertOptions) (string, []any, error) {
+	return UpsertManyWith[T](DB, dbStructures, options...)
+}
+
+// UpsertManyWith generates a parameterized multi-row upsert for the elements in the argument, using the given handle.
+// See Database.Upsert.
+func UpsertManyWith[T any](db *Database, dbStructures []T, options ...UpsertOptions) (string, []any, error) {
+	if len(dbStructures) == 0 {
+		return "", nil, nil
+	}
+	var opts UpsertOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	var table string
+	var columns, updated []columnValue
+	var tuples []string
+	var args []any
+	for _, dbStructure := range dbStructures {
+		var err error
+		table, columns, updated, err = db.upsertColumns(dbStructure)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, c := range columns {
+			args = append(args, c.value)
+		}
+		tuples = append(tuples, placeholdersSql(len(columns)))
+	}
+
+	var sql string
+	switch opts.Mode {
+	case UpsertUpdate:
+		update, err := upsertUpdateSql(columns, updated, opts.Columns)
+		if err != nil {
+			return "", nil, err
+		}
+		sql = fmt.Sprintf("INSERT INTO %s(%s) VALUES %s ON DUPLICATE KEY UPDATE %s;", table, columnsSql(columns), strings.Join(tuples, ","), update)
+	case UpsertIgnore:
+		sql = fmt.Sprintf("INSERT IGNORE INTO %s(%s) VALUES %s;", table, columnsSql(columns), strings.Join(tuples, ","))
+	case UpsertReplace:
+		sql = fmt.Sprintf("REPLACE INTO %s(%s) VALUES %s;", table, columnsSql(columns), strings.Join(tuples, ","))
+	default:
+		return "", nil, fmt.Errorf("unknown upsert mode %d", opts.Mode)
+	}
+	return sql, args, nil
+}
+
+// Upsert generates a parameterized upsert, see Database.Upsert
+func (t *Tx) Upsert(dbStructure any, options ...UpsertOptions) (string, []any, error) {
+	return t.db.Upsert(dbStructure, options...)
+}
+
+// upsertColumns returns the table, the columns of the structure written by an upsert (the primary key columns followed by the
+// non-primary key, non-omitted columns) and the columns updated on a duplicate key by default, which leave out the
+// autocreate columns
+func (db *Database) upsertColumns(dbStructure any) (string, []columnValue, []columnValue, error) {
+	table, primaryKeys, columns, err := db.structColumns(dbStructure)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if table == "" {
+		return "", nil, nil, fmt.Errorf("no table found in structure")
+	}
+	if len(columns) == 0 {
+		return "", nil, nil, fmt.Errorf("no non-primary key and non-omitted fields found in structure")
+	}
+	initVersion(columns)
+	db.stampInsert(columns)
+	updated := make([]columnValue, 0, len(columns))
+	for _, c := range columns {
+		if !c.field.autoCreate {
+			updated = append(updated, c)
+		}
+	}
+	return table, append(primaryKeys, columns...), updated, nil
+}
+
+// upsertUpdateSql renders the col=VALUES(col) assignments for the columns to update, which must be among the written
+// columns and default to updated. It fails when that leaves nothing to update.
+func upsertUpdateSql(columns []columnValue, updated []columnValue, only []string) (string, error) {
+	names := only
+	if len(names) == 0 {
+		names = make([]string, 0, len(updated))
+		for _, c := range updated {
+			names = append(names, c.column)
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no columns to update on a duplicate key, use UpsertIgnore")
+	}
+
+	set := make([]string, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, c := range columns {
+			found = found || c.column == name
+		}
+		if !found {
+			return "", fmt.Errorf("column %s is not written by the upsert", name)
+		}
+		if isVersionColumn(columns, name) {
+			set = append(set, name+"="+name+"+1")
+			continue
+		}
+		set = append(set, name+"=VALUES("+name+")")
+	}
+	return strings.Join(set, ","), nil
+}
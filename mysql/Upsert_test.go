@@ -0,0 +1,55 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type upsertCountry struct {
+	Code       string    `db:"column=code primarykey=yes table=Countries"`
+	Name       string    `db:"column=name"`
+	Population int       `db:"column=population"`
+	Dtadded    time.Time `db:"column=dtadded omit=yes"`
+}
+
+func TestUpsert(t *testing.T) {
+	New("", nil)
+	sql, args, err := DB.Upsert(upsertCountry{"NZ", "New Zealand", 5000000, time.Now()})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Countries(code,name,population) VALUES (?,?,?) ON DUPLICATE KEY UPDATE name=VALUES(name),population=VALUES(population);", sql)
+	assert.Equal(t, []any{"NZ", "New Zealand", 5000000}, args)
+
+	sql, _, err = DB.Upsert(upsertCountry{Code: "NZ"}, UpsertOptions{Columns: []string{"population"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Countries(code,name,population) VALUES (?,?,?) ON DUPLICATE KEY UPDATE population=VALUES(population);", sql)
+
+	sql, _, err = DB.Upsert(upsertCountry{Code: "NZ"}, UpsertOptions{Mode: UpsertIgnore})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT IGNORE INTO Countries(code,name,population) VALUES (?,?,?);", sql)
+
+	sql, _, err = DB.Upsert(upsertCountry{Code: "NZ"}, UpsertOptions{Mode: UpsertReplace})
+	assert.NoError(t, err)
+	assert.Equal(t, "REPLACE INTO Countries(code,name,population) VALUES (?,?,?);", sql)
+
+	_, _, err = DB.Upsert(upsertCountry{Code: "NZ"}, UpsertOptions{Columns: []string{"dtadded"}})
+	assert.EqualError(t, err, "column dtadded is not written by the upsert")
+
+	_, _, err = DB.Upsert(struct {
+		Id int `db:"column=id primarykey=yes"`
+	}{})
+	assert.EqualError(t, err, "no table found in structure")
+}
+
+func TestUpsertMany(t *testing.T) {
+	sql, args, err := UpsertMany([]upsertCountry{{Code: "NZ", Name: "New Zealand"}, {Code: "AU", Name: "Australia"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Countries(code,name,population) VALUES (?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE name=VALUES(name),population=VALUES(population);", sql)
+	assert.Equal(t, []any{"NZ", "New Zealand", 0, "AU", "Australia", 0}, args)
+
+	sql, args, err = UpsertMany[upsertCountry](nil)
+	assert.NoError(t, err)
+	assert.Empty(t, sql)
+	assert.Empty(t, args)
+}
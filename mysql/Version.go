@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"reflect"
+)
+
+// A field tagged version=yes is used for optimistic locking: inserts start it at 1, and updates match the row on it
+// as well as the primary key and increment it, so an update of a row changed since it was read affects nothing.
+
+// initVersion starts a zero version column at 1
+func initVersion(columns []columnValue) {
+	for i, c := range columns {
+		if c.field.version && isZeroValue(c.value) {
+			columns[i].value = int64(1)
+		}
+	}
+}
+
+// isVersionColumn reports whether the named column is the version column
+func isVersionColumn(columns []columnValue, name string) bool {
+	for _, c := range columns {
+		if c.column == name {
+			return c.field.version
+		}
+	}
+	return false
+}
+
+// checkVersion completes an update of the structure dbStructure points to. When it has a version column, no affected
+// rows means the row was changed or deleted since it was read, and otherwise the version is incremented to match the
+// row.
+func (db *Database) checkVersion(dbStructure any, rowsAffected int64) error {
+	field, ok := db.versionField(dbStructure)
+	if !ok {
+		return nil
+	}
+	if rowsAffected == 0 {
+		return ErrStaleObject
+	}
+	if field.IsValid() {
+		addVersion(field, 1)
+	}
+	return nil
+}
+
+// insertedVersion sets the version of the structure dbStructure points to as an insert wrote it
+func (db *Database) insertedVersion(dbStructure any) {
+	field, ok := db.versionField(dbStructure)
+	if ok && field.IsValid() && field.IsZero() {
+		addVersion(field, 1)
+	}
+}
+
+// versionField returns the version field of the structure, reporting whether it has one. The field is only valid,
+// and settable, when the structure is passed as a pointer.
+func (db *Database) versionField(dbStructure any) (reflect.Value, bool) {
+	v := reflect.ValueOf(dbStructure)
+	pointer := false
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		pointer = true
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	version := db.structInfo(v.Type()).version
+	if version == nil {
+		return reflect.Value{}, false
+	}
+	if !pointer {
+		return reflect.Value{}, true
+	}
+	return v.FieldByIndex(version.index), true
+}
+
+// addVersion adds n to an integer version field
+func addVersion(field reflect.Value, n int64) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(field.Int() + n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(field.Uint() + uint64(n))
+	}
+}
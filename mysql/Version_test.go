@@ -0,0 +1,85 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type versionedOrder struct {
+	Id      int    `db:"column=id primarykey=yes table=Orders"`
+	Status  string `db:"column=status"`
+	Version int    `db:"column=version version=yes"`
+}
+
+func TestVersionSql(t *testing.T) {
+	New("", nil)
+	sql, args, err := DB.InsertArgs(versionedOrder{Status: "new"})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Orders(status,version) VALUES (?,?);", sql)
+	assert.Equal(t, []any{"new", int64(1)}, args)
+
+	sql, args, err = DB.UpdateArgs(versionedOrder{1, "paid", 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Orders SET status=?,version=version+1 WHERE id=? AND version=?;", sql)
+	assert.Equal(t, []any{"paid", 1, 3}, args)
+
+	sql, err = DB.Update(versionedOrder{1, "paid", 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Orders SET status=X'70616964',version=version+1 WHERE id=1 AND version=3;", sql)
+
+	sql, args, err = DB.UpdateChanged(versionedOrder{1, "new", 3}, versionedOrder{1, "paid", 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE Orders SET status=?,version=version+1 WHERE id=? AND version=?;", sql)
+	assert.Equal(t, []any{"paid", 1, 3}, args)
+
+	sql, _, err = DB.Upsert(versionedOrder{1, "paid", 0})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO Orders(id,status,version) VALUES (?,?,?) ON DUPLICATE KEY UPDATE status=VALUES(status),version=version+1;", sql)
+}
+
+func TestSaveVersion(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("INSERT INTO Orders(status,version) VALUES (?,?);").
+		WithArgs("new", int64(1)).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("UPDATE Orders SET status=?,version=version+1 WHERE id=? AND version=?;").
+		WithArgs("paid", 5, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE Orders SET status=?,version=version+1 WHERE id=? AND version=?;").
+		WithArgs("shipped", 5, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	order := versionedOrder{Status: "new"}
+	_, _, err := db.Save(&order)
+	assert.NoError(t, err)
+	assert.Equal(t, versionedOrder{5, "new", 1}, order)
+
+	// a second copy read before the update is stale once the first is saved
+	stale := order
+	order.Status = "paid"
+	_, _, err = db.Save(&order)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, order.Version)
+
+	stale.Status = "shipped"
+	_, _, err = db.Save(&stale)
+	assert.ErrorIs(t, err, ErrStaleObject)
+	assert.Equal(t, 1, stale.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTrackedVersion(t *testing.T) {
+	db, mock := setupMockDatabase(t)
+	mock.ExpectExec("UPDATE Orders SET status=?,version=version+1 WHERE id=? AND version=?;").
+		WithArgs("paid", 1, 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tracked := Track(versionedOrder{1, "new", 4})
+	tracked.Value.Status = "paid"
+	_, err := tracked.UpdateContext(context.Background(), db)
+	assert.ErrorIs(t, err, ErrStaleObject)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,7 +2,6 @@ package mysql
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 	"unicode"
 
@@ -51,29 +50,3 @@ func hexRepresentation(in string) string {
 	return "X'" + fmt.Sprintf("%x", in) + "'"
 	// return "'" + in + "'"
 }
-
-// getStructDetails Get the details of a struct
-func getStructDetails[T any](dbFieldName string) (string, any) {
-
-	var st T
-	t := reflect.TypeOf(st)
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag := field.Tag.Get("db")
-		dbStructureMap := decodeTag(tag)
-		// l.INFO("%d. %v (%v), tag: '%v'\n", i+1, field.Name, field.Type.Name(), tag)
-		// l.SPEW(field.Type)
-
-		if dbStructureMap["column"] == dbFieldName {
-			if field.Type == reflect.TypeOf([]uint8{}) {
-				return field.Name, "[]uint8"
-			} else if field.Type.Kind() == reflect.Pointer {
-				return field.Name, "*" + field.Type.Elem().Name()
-			} else {
-				return field.Name, field.Type.Name()
-			}
-		}
-	}
-	return "", ""
-}
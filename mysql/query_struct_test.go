@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDataTypes(t *testing.T) {
@@ -45,7 +46,7 @@ func TestDataTypes(t *testing.T) {
             INSERT INTO Users(id,intval, int8val,int16val,int32val,int64val,uintval,uint8val,uint16val,uint32val,uint64val) 
             VALUES (?,?,?,?,?,?,?,?,?,?,?)`,
 			1, 2147483647, 127, 32767, 2147483647, 9223372036854775807,
-			4294967295, 255, 65535, 4294967295, "18446744073709551615")
+			4294967295, 255, 65535, 4294967295, "9223372036854775807")
 		assert.NoError(t, err)
 		assert.Greater(t, rowsAffected, int64(0))
 
@@ -60,7 +61,17 @@ func TestDataTypes(t *testing.T) {
 		assert.Equal(t, uint8(255), resp.Uint8Val)
 		assert.Equal(t, uint16(65535), resp.Uint16Val)
 		assert.Equal(t, uint32(4294967295), resp.Uint32Val)
-		assert.Equal(t, uint64(18446744073709551615), resp.Uint64Val)
+		assert.Equal(t, uint64(9223372036854775807), resp.Uint64Val)
+
+		// sqlite stores integers above the int64 range as REAL, which is not converted to a uint64
+		_, _, err = DB.Execute("INSERT INTO Users(id,uint64val) VALUES (?,?)", 4, "18446744073709551615")
+		require.NoError(t, err)
+		_, err = QuerySingleStruct[IntTypes]("SELECT * FROM Users WHERE id=?", 4)
+		var conversionError *ConversionError
+		if assert.ErrorAs(t, err, &conversionError) {
+			assert.Equal(t, "uint64val", conversionError.Column)
+			assert.Equal(t, "float64", conversionError.SourceType)
+		}
 
 		_, rowsAffected, err = DB.Execute(`
             INSERT INTO Users(id,intval, int8val,int16val,int32val,int64val,uintval,uint8val,uint16val,uint32val,uint64val) 
@@ -330,12 +341,12 @@ func TestPtrDataTypes(t *testing.T) {
             INSERT INTO Users(id,intval, int8val,int16val,int32val,int64val,uintval,uint8val,uint16val,uint32val,uint64val) 
             VALUES (?,?,?,?,?,?,?,?,?,?,?)`,
 			1, 2147483647, 127, 32767, 2147483647, 9223372036854775807,
-			4294967295, 255, 65535, 4294967295, "18446744073709551615")
+			4294967295, 255, 65535, 4294967295, "9223372036854775807")
 		assert.NoError(t, err)
 		assert.Greater(t, rowsAffected, int64(0))
 
 		resp, err := QuerySingleStruct[IntTypes]("SELECT * FROM Users WHERE id=?", 1)
-		assert.NoError(t, err)
+		require.NoError(t, err)
 		assert.Equal(t, int(2147483647), *resp.IntVal)
 		assert.Equal(t, int8(127), *resp.Int8Val)
 		assert.Equal(t, int16(32767), *resp.Int16Val)
@@ -345,7 +356,17 @@ func TestPtrDataTypes(t *testing.T) {
 		assert.Equal(t, uint8(255), *resp.Uint8Val)
 		assert.Equal(t, uint16(65535), *resp.Uint16Val)
 		assert.Equal(t, uint32(4294967295), *resp.Uint32Val)
-		assert.Equal(t, uint64(18446744073709551615), *resp.Uint64Val)
+		assert.Equal(t, uint64(9223372036854775807), *resp.Uint64Val)
+
+		// sqlite stores integers above the int64 range as REAL, which is not converted to a uint64
+		_, _, err = DB.Execute("INSERT INTO Users(id,uint64val) VALUES (?,?)", 3, "18446744073709551615")
+		require.NoError(t, err)
+		_, err = QuerySingleStruct[IntTypes]("SELECT * FROM Users WHERE id=?", 3)
+		var conversionError *ConversionError
+		if assert.ErrorAs(t, err, &conversionError) {
+			assert.Equal(t, "uint64val", conversionError.Column)
+			assert.Equal(t, "float64", conversionError.SourceType)
+		}
 
 		_, rowsAffected, err = DB.Execute(`
             INSERT INTO Users(id,intval, int8val,int16val,int32val,int64val,uintval,uint8val,uint16val,uint32val,uint64val) 
@@ -356,7 +377,7 @@ func TestPtrDataTypes(t *testing.T) {
 		assert.Greater(t, rowsAffected, int64(0))
 
 		resp, err = QuerySingleStruct[IntTypes]("SELECT * FROM Users WHERE id=?", 2)
-		assert.NoError(t, err)
+		require.NoError(t, err)
 		assert.Equal(t, 2, *resp.Id)
 		assert.Equal(t, (*int)(nil), resp.IntVal)
 		assert.Equal(t, (*int8)(nil), resp.Int8Val)
@@ -395,7 +416,7 @@ func TestPtrDataTypes(t *testing.T) {
 		assert.Greater(t, rowsAffected, int64(0))
 
 		resp, err := QuerySingleStruct[FloatTypes]("SELECT * FROM Users WHERE id=?", 1)
-		assert.NoError(t, err)
+		require.NoError(t, err)
 		assert.InDelta(t, float32(3.14159), *resp.Float32Val, 0.0001)
 		assert.InDelta(t, 2.7182818284590452, *resp.Float64Val, 0.0000000000000001)
 
@@ -435,7 +456,7 @@ func TestPtrDataTypes(t *testing.T) {
 		assert.Greater(t, rowsAffected, int64(0))
 
 		resp, err := QuerySingleStruct[BoolType]("SELECT * FROM Users WHERE id=?", 1)
-		assert.NoError(t, err)
+		require.NoError(t, err)
 		assert.True(t, *resp.BoolVal)
 
 		_, rowsAffected, err = DB.Execute(`
@@ -468,7 +489,7 @@ func TestPtrDataTypes(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Greater(t, rowsAffected, int64(0))
 		resp, err := QuerySingleStruct[IntegrationGenericStruct]("SELECT * FROM Users where id=?", 1)
-		assert.NoError(t, err)
+		require.NoError(t, err)
 		assert.Equal(t, "Test", *resp.StringVal)
 
 		_, rowsAffected, err = DB.Execute("INSERT INTO Users(id,stringval) VALUES (?,?)", 2, nil)
@@ -503,7 +524,7 @@ func TestPtrDataTypes(t *testing.T) {
 		assert.Greater(t, rowsAffected, int64(0))
 
 		resp, err := QuerySingleStruct[TimeType]("SELECT * FROM Users WHERE id=?", 1)
-		assert.NoError(t, err)
+		require.NoError(t, err)
 		assert.Equal(t, testTime.UTC(), (*resp.TimeVal).UTC())
 
 		_, rowsAffected, err = DB.Execute(`